@@ -0,0 +1,175 @@
+package hangulize
+
+import (
+	"bufio"
+	"io"
+	"sync"
+)
+
+// batchSize is the number of lines HangulizeReader transcribes at once.
+const batchSize = 1024
+
+// maxLineSize is the length of the longest line HangulizeReader accepts.
+const maxLineSize = 1024 * 1024
+
+// WithWorkers makes a Hangulizer fan out HangulizeBatch and HangulizeReader
+// to the given number of goroutines. By default, they run in the caller's
+// goroutine.
+func WithWorkers(n int) Option {
+	return func(h *Hangulizer) {
+		h.workers = n
+	}
+}
+
+// HangulizeBatch transcribes many loanwords into Hangul. The results are in
+// the same order as the words.
+//
+// It is cheaper than calling Hangulize for each word because the internal
+// buffers are reused across the words.
+func (h *Hangulizer) HangulizeBatch(words []string) []string {
+	results := make([]string, len(words))
+
+	workers := h.workers
+	if workers > len(words) {
+		workers = len(words)
+	}
+
+	if workers <= 1 {
+		h.hangulizeInto(results, words)
+		return results
+	}
+
+	// Each worker takes a contiguous chunk of the words.
+	chunk := (len(words) + workers - 1) / workers
+
+	var wg sync.WaitGroup
+
+	for start := 0; start < len(words); start += chunk {
+		stop := start + chunk
+		if stop > len(words) {
+			stop = len(words)
+		}
+
+		wg.Add(1)
+		go func(start, stop int) {
+			defer wg.Done()
+			h.hangulizeInto(results[start:stop], words[start:stop])
+		}(start, stop)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// HangulizeReader transcribes each line from r and writes the results to w
+// line by line.
+//
+// The lines are transcribed in batches. When r has no line ready, the lines
+// read so far are transcribed and written without waiting for a full batch.
+// So it answers an interactive pipe line by line.
+//
+// A line longer than 1 MiB fails with bufio.ErrTooLong. The lines before the
+// error are still written.
+//
+func (h *Hangulizer) HangulizeReader(r io.Reader, w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	// Read the lines in another goroutine to tell whether r would block.
+	lines := make(chan string, batchSize)
+	scanErr := make(chan error, 1)
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		defer close(lines)
+
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(nil, maxLineSize)
+
+		for scanner.Scan() {
+			select {
+			case lines <- scanner.Text():
+			case <-done:
+				return
+			}
+		}
+		scanErr <- scanner.Err()
+	}()
+
+	words := make([]string, 0, batchSize)
+
+	flush := func() error {
+		for _, result := range h.HangulizeBatch(words) {
+			if _, err := bw.WriteString(result); err != nil {
+				return err
+			}
+			if err := bw.WriteByte('\n'); err != nil {
+				return err
+			}
+		}
+		words = words[:0]
+		return nil
+	}
+
+	for {
+		var line string
+		var ok bool
+
+		select {
+		case line, ok = <-lines:
+		default:
+			// r would block. Write the partial batch before waiting.
+			if len(words) != 0 {
+				if err := flush(); err != nil {
+					return err
+				}
+				if err := bw.Flush(); err != nil {
+					return err
+				}
+			}
+			line, ok = <-lines
+		}
+
+		if !ok {
+			break
+		}
+
+		words = append(words, line)
+
+		if len(words) == batchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		return err
+	}
+
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+
+	return <-scanErr
+}
+
+// hangulizeInto transcribes the words into the results with a single
+// pipeline.
+func (h *Hangulizer) hangulizeInto(results []string, words []string) {
+	p := pipeline{h: h}
+
+	for i, word := range words {
+		if result, _, ok := h.cache.Get(word); ok {
+			results[i] = result
+			continue
+		}
+
+		result, err := p.forward(word)
+		results[i] = result
+
+		if cacheable(err) {
+			h.cache.Put(word, result, err)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package hangulize
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var batchWords = []string{
+	"Cappuccino",
+	"gloria",
+	"",
+	"glor,ia",
+	"Pinocchio",
+	"Cappuccino",
+}
+
+func TestHangulizeBatch(t *testing.T) {
+	h := NewHangulizer(loadSpec("ita"))
+
+	results := h.HangulizeBatch(batchWords)
+
+	assert.Len(t, results, len(batchWords))
+	for i, word := range batchWords {
+		assert.Equal(t, h.Hangulize(word), results[i], word)
+	}
+}
+
+func TestHangulizeBatchWorkers(t *testing.T) {
+	h := NewHangulizer(loadSpec("ita"))
+
+	for _, n := range []int{2, 3, 100} {
+		hw := NewHangulizer(loadSpec("ita"), WithWorkers(n))
+		assert.Equal(t, h.HangulizeBatch(batchWords), hw.HangulizeBatch(batchWords))
+	}
+}
+
+func TestHangulizeBatchEmpty(t *testing.T) {
+	h := NewHangulizer(loadSpec("ita"), WithWorkers(4))
+	assert.Empty(t, h.HangulizeBatch(nil))
+}
+
+func TestHangulizeBatchCache(t *testing.T) {
+	h := NewHangulizer(loadSpec("ita"), WithCache(10))
+
+	h.HangulizeBatch(batchWords)
+
+	stats := h.CacheStats()
+	assert.Equal(t, uint64(1), stats.Hits)
+	assert.Equal(t, uint64(5), stats.Misses)
+}
+
+func TestHangulizeReader(t *testing.T) {
+	h := NewHangulizer(loadSpec("ita"), WithWorkers(2))
+
+	r := strings.NewReader(strings.Join(batchWords, "\n"))
+	var w bytes.Buffer
+
+	err := h.HangulizeReader(r, &w)
+	assert.NoError(t, err)
+
+	expected := strings.Join(h.HangulizeBatch(batchWords), "\n") + "\n"
+	assert.Equal(t, expected, w.String())
+}
+
+func TestHangulizeReaderManyLines(t *testing.T) {
+	h := NewHangulizer(loadSpec("ita"))
+
+	n := batchSize*2 + 1
+	r := strings.NewReader(strings.Repeat("gloria\n", n))
+	var w bytes.Buffer
+
+	err := h.HangulizeReader(r, &w)
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Repeat("글로리아\n", n), w.String())
+}
+
+func TestHangulizeReaderLongLine(t *testing.T) {
+	h := NewHangulizer(loadSpec("ita"))
+
+	// Longer than the default limit of bufio.Scanner. Digits are kept as they
+	// are.
+	long := strings.Repeat("1", 70000)
+
+	r := strings.NewReader("gloria\n" + long + "\n")
+	var w bytes.Buffer
+
+	err := h.HangulizeReader(r, &w)
+	assert.NoError(t, err)
+	assert.Equal(t, "글로리아\n"+long+"\n", w.String())
+}
+
+func TestHangulizeReaderTooLongLine(t *testing.T) {
+	h := NewHangulizer(loadSpec("ita"))
+
+	r := strings.NewReader("gloria\n" + strings.Repeat("a", maxLineSize+1) + "\n")
+	var w bytes.Buffer
+
+	err := h.HangulizeReader(r, &w)
+	assert.Equal(t, bufio.ErrTooLong, err)
+	assert.Equal(t, "글로리아\n", w.String())
+}
+
+func TestHangulizeReaderInteractive(t *testing.T) {
+	h := NewHangulizer(loadSpec("ita"))
+
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- h.HangulizeReader(inR, outW)
+		outW.Close()
+	}()
+
+	results := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(outR)
+		for scanner.Scan() {
+			results <- scanner.Text()
+		}
+		close(results)
+	}()
+
+	// Each result comes before the next line is written.
+	for _, word := range []string{"gloria", "Pinocchio"} {
+		_, err := io.WriteString(inW, word+"\n")
+		assert.NoError(t, err)
+
+		select {
+		case result := <-results:
+			assert.Equal(t, h.Hangulize(word), result)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no result for %#v", word)
+		}
+	}
+
+	inW.Close()
+	assert.NoError(t, <-errc)
+}
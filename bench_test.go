@@ -16,6 +16,17 @@ func BenchmarkCappuccino(b *testing.B) {
 	}
 }
 
+func BenchmarkCappuccinoCached(b *testing.B) {
+	spec, _ := LoadSpec("ita")
+	h := NewHangulizer(spec, WithCache(1))
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		h.Hangulize("Cappuccino")
+	}
+}
+
 func BenchmarkCappuccinoTrace(b *testing.B) {
 	spec, _ := LoadSpec("ita")
 	h := NewHangulizer(spec)
@@ -38,6 +49,29 @@ func BenchmarkJulianaLouiseEmmaMarieWilhelmina(b *testing.B) {
 	}
 }
 
+func BenchmarkManyWords(b *testing.B) {
+	spec, _ := LoadSpec("nld")
+	h := NewHangulizer(spec)
+
+	words := strings.Fields("Juliana Louise Emma Marie Wilhelmina")
+
+	b.Run("Hangulize", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, w := range words {
+				h.Hangulize(w)
+			}
+		}
+	})
+
+	b.Run("HangulizeBatch", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			h.HangulizeBatch(words)
+		}
+	})
+}
+
 func BenchmarkVeryLongWord(b *testing.B) {
 	spec, _ := LoadSpec("deu")
 	h := NewHangulizer(spec)
@@ -0,0 +1,132 @@
+package hangulize
+
+import (
+	"container/list"
+	"sync"
+)
+
+// CacheStats describes the usage of a transcription cache.
+type CacheStats struct {
+	Hits   uint64 // The number of words found in the cache.
+	Misses uint64 // The number of words transcribed by the pipeline.
+	Len    int    // The number of cached words.
+	Size   int    // The maximum number of cached words.
+}
+
+// lruCache keeps transcribed words up to a limited size. When it is full, the
+// least recently used word is evicted. It is safe for concurrent use.
+//
+// Like tracer, a nil lruCache is valid. It just doesn't cache anything.
+type lruCache struct {
+	mu    sync.Mutex
+	size  int
+	ll    *list.List
+	items map[string]*list.Element
+
+	hits   uint64
+	misses uint64
+}
+
+type lruEntry struct {
+	word   string
+	result string
+	err    error // The OverlapError found with the result.
+}
+
+// newLRUCache creates an lruCache. It returns nil if the size is not
+// positive.
+func newLRUCache(size int) *lruCache {
+	if size <= 0 {
+		return nil
+	}
+	return &lruCache{
+		size:  size,
+		ll:    list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+// Get finds the cached result for a word and the error cached together.
+func (c *lruCache) Get(word string) (string, error, bool) {
+	if c == nil {
+		return "", nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[word]
+	if !ok {
+		c.misses++
+		return "", nil, false
+	}
+
+	c.hits++
+	c.ll.MoveToFront(el)
+
+	entry := el.Value.(*lruEntry)
+	return entry.result, entry.err, true
+}
+
+// Put caches the result for a word with the error. The error should be
+// reproducible by the same word, such as an OverlapError. It evicts the least
+// recently used word if the cache is full.
+func (c *lruCache) Put(word, result string, err error) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[word]; ok {
+		entry := el.Value.(*lruEntry)
+		entry.result, entry.err = result, err
+		c.ll.MoveToFront(el)
+		return
+	}
+
+	c.items[word] = c.ll.PushFront(&lruEntry{word, result, err})
+
+	if c.ll.Len() > c.size {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry).word)
+	}
+}
+
+// Purge discards all cached words. The hit/miss counters are kept.
+func (c *lruCache) Purge() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ll.Init()
+	c.items = make(map[string]*list.Element)
+}
+
+// Stats returns the current usage of the cache.
+func (c *lruCache) Stats() CacheStats {
+	if c == nil {
+		return CacheStats{}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return CacheStats{c.hits, c.misses, c.ll.Len(), c.size}
+}
+
+// cacheable determines whether a result can be cached with the error. An
+// OverlapError is cached together because the same word always gets it. The other errors, such as a failure of the phonemizer, may
+// not happen again.
+func cacheable(err error) bool {
+	switch err.(type) {
+	case nil, *OverlapError:
+		return true
+	}
+	return false
+}
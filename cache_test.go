@@ -0,0 +1,95 @@
+package hangulize
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRUCacheEviction(t *testing.T) {
+	c := newLRUCache(2)
+
+	c.Put("a", "ㅏ", nil)
+	c.Put("b", "ㅂ", nil)
+
+	// "a" becomes the most recently used.
+	_, _, ok := c.Get("a")
+	assert.True(t, ok)
+
+	// "b" is evicted.
+	c.Put("c", "ㅊ", nil)
+
+	_, _, ok = c.Get("b")
+	assert.False(t, ok)
+
+	result, _, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, "ㅏ", result)
+
+	assert.Equal(t, CacheStats{Hits: 2, Misses: 1, Len: 2, Size: 2}, c.Stats())
+}
+
+func TestLRUCacheNil(t *testing.T) {
+	assert.Nil(t, newLRUCache(0))
+
+	var c *lruCache
+	c.Put("a", "ㅏ", nil)
+
+	_, _, ok := c.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, CacheStats{}, c.Stats())
+}
+
+func TestHangulizerCache(t *testing.T) {
+	h := NewHangulizer(loadSpec("ita"), WithCache(10))
+
+	assert.Equal(t, "카푸치노", h.Hangulize("Cappuccino"))
+	assert.Equal(t, "카푸치노", h.Hangulize("Cappuccino"))
+
+	stats := h.CacheStats()
+	assert.Equal(t, uint64(1), stats.Hits)
+	assert.Equal(t, uint64(1), stats.Misses)
+	assert.Equal(t, 1, stats.Len)
+}
+
+func TestHangulizerCacheBypassedByTrace(t *testing.T) {
+	h := NewHangulizer(loadSpec("ita"), WithCache(10))
+
+	h.Hangulize("Cappuccino")
+	word, tr := h.HangulizeTrace("Cappuccino")
+
+	assert.Equal(t, "카푸치노", word)
+	assert.NotEmpty(t, tr)
+	assert.Equal(t, uint64(0), h.CacheStats().Hits)
+}
+
+func TestHangulizerCachePurgedByPhonemizer(t *testing.T) {
+	h := NewHangulizer(loadSpec("jpn"), WithCache(10))
+
+	h.Hangulize("1234")
+	assert.Equal(t, 1, h.CacheStats().Len)
+
+	h.UsePhonemizer(&stubFurigana{})
+	assert.Equal(t, 0, h.CacheStats().Len)
+	assert.Equal(t, "스타부", h.Hangulize("1234"))
+}
+
+func TestHangulizerCacheConcurrency(t *testing.T) {
+	h := NewHangulizer(loadSpec("ita"), WithCache(2))
+	words := []string{"Cappuccino", "gloria", "Pinocchio"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 30; i++ {
+		wg.Add(1)
+		go func(word string) {
+			defer wg.Done()
+			h.Hangulize(word)
+		}(words[i%len(words)])
+	}
+	wg.Wait()
+
+	stats := h.CacheStats()
+	assert.Equal(t, uint64(30), stats.Hits+stats.Misses)
+	assert.Equal(t, 2, stats.Len)
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/hangulize/hangulize"
+)
+
+// runDiff reports the words in the corpus which are transcribed differently
+// by 2 versions of a spec. Each word is followed by the first traces which
+// diverge. For example, deu-new.hgl replaces "ch" -> "x" with "ch" -> "k" and
+// words.txt has München, Berlin and Hamburg:
+//
+//   $ hangulize diff deu.hgl deu-new.hgl words.txt
+//   München	뮌헨	뮝켄
+//     - [rewrite] "mynxen" "ch" -> "x"
+//     + [rewrite] "mynken" "ch" -> "k"
+//   1 of 3 words changed
+//
+func runDiff(args []string) error {
+	if len(args) != 3 {
+		return errors.New("requires OLD.hgl, NEW.hgl and CORPUS")
+	}
+
+	oldSpec, err := readSpec(args[0])
+	if err != nil {
+		return err
+	}
+
+	newSpec, err := readSpec(args[1])
+	if err != nil {
+		return err
+	}
+
+	words, err := readWords(args[2])
+	if err != nil {
+		return err
+	}
+
+	oldH := hangulize.NewHangulizer(oldSpec)
+	newH := hangulize.NewHangulizer(newSpec)
+
+	diffs := hangulize.Diff(oldH, newH, words)
+
+	for _, d := range diffs {
+		fmt.Printf("%s\t%s\t%s\n", d.Word, d.Old, d.New)
+
+		if d.OldTrace != nil {
+			fmt.Printf("  - %s\n", d.OldTrace)
+		}
+		if d.NewTrace != nil {
+			fmt.Printf("  + %s\n", d.NewTrace)
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "%d of %d words changed\n", len(diffs), len(words))
+	return nil
+}
+
+func readSpec(path string) (*hangulize.Spec, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	spec, err := hangulize.ParseSpec(f)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %s", path)
+	}
+
+	// Don't compare unphonemized words.
+	if id := spec.Lang.Phonemizer; id != "" {
+		if _, ok := hangulize.GetPhonemizer(id); !ok {
+			return nil, errors.Wrapf(hangulize.ErrPhonemizerMissing, "%s requires %#v", path, id)
+		}
+	}
+
+	return spec, nil
+}
+
+func readWords(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return hangulize.ReadWords(f)
+}
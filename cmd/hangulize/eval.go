@@ -0,0 +1,58 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/hangulize/hangulize/eval"
+	"github.com/hangulize/hangulize/golden"
+)
+
+// runEval evaluates the golden corpora in a directory with the character
+// error rates and prints the most frequent confusion pairs.
+//
+//   $ hangulize eval testdata/golden
+//   ita      acc=66.67% syllable-cer=10.00% jamo-cer=5.00% (3 words)
+//
+//   draft    acc=66.67% syllable-cer=10.00% jamo-cer=5.00% (3 words)
+//   total    acc=66.67% syllable-cer=10.00% jamo-cer=5.00% (3 words)
+//
+func runEval(args []string) error {
+	flags := flag.NewFlagSet("eval", flag.ExitOnError)
+	n := flags.Int("n", 10, "the number of confusion pairs to print")
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		return errors.New("requires DIR")
+	}
+
+	paths, err := filepath.Glob(filepath.Join(flags.Arg(0), "*"+golden.Ext))
+	if err != nil {
+		return err
+	}
+
+	e := eval.New()
+
+	for _, path := range paths {
+		lang := strings.TrimSuffix(filepath.Base(path), golden.Ext)
+
+		h, err := golden.NewHangulizer(lang)
+		if err != nil {
+			return err
+		}
+
+		pairs, err := golden.Load(path)
+		if err != nil {
+			return err
+		}
+
+		e.Run(h, pairs)
+	}
+
+	e.Report(os.Stdout, *n)
+	return nil
+}
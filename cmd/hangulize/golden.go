@@ -0,0 +1,92 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/hangulize/hangulize/golden"
+)
+
+// runGolden runs the golden corpora in a directory and reports the accuracy
+// of each language. It fails if there's a regression from the baseline.
+//
+//   $ hangulize golden testdata/golden testdata/baseline.tsv
+//   ita: 2/3 (66.67%)
+//
+// With -update, it overwrites the baseline with the current failures instead.
+//
+// With -history, it appends the accuracy of each language to the history file
+// and shows the change from the last record:
+//
+//   $ hangulize golden -history testdata/history.tsv testdata/golden testdata/baseline.tsv
+//   ita: 2/3 (66.67%) +33.33%p
+//
+func runGolden(args []string) error {
+	flags := flag.NewFlagSet("golden", flag.ExitOnError)
+	update := flags.Bool("update", false, "overwrite the baseline")
+	historyPath := flags.String("history", "", "append the accuracy to the history file")
+	flags.Parse(args)
+
+	if flags.NArg() != 2 {
+		return errors.New("requires DIR and BASELINE")
+	}
+	dir, baselinePath := flags.Arg(0), flags.Arg(1)
+
+	results, err := golden.RunDir(dir)
+	if err != nil {
+		return err
+	}
+
+	var history []golden.Record
+	if *historyPath != "" {
+		history, err = golden.LoadHistory(*historyPath)
+		if err != nil {
+			return err
+		}
+
+		records := golden.NewRecords(results, time.Now())
+		if err := golden.AppendHistory(*historyPath, records); err != nil {
+			return err
+		}
+	}
+
+	printResult := func(r *golden.Result) {
+		last, ok := golden.Last(history, r.Lang)
+		if !ok {
+			fmt.Println(r)
+			return
+		}
+		fmt.Printf("%s %+.2f%%p\n", r, (r.Accuracy()-last.Accuracy())*100)
+	}
+
+	if *update {
+		for _, r := range results {
+			printResult(r)
+		}
+		return golden.NewBaseline(results).Save(baselinePath)
+	}
+
+	baseline, err := golden.LoadBaseline(baselinePath)
+	if err != nil {
+		return err
+	}
+
+	regressions := 0
+
+	for _, r := range results {
+		printResult(r)
+
+		for _, f := range baseline.Regressions(r) {
+			fmt.Printf("  %s\t%s\t%s\n", f.Word, f.Expected, f.Got)
+			regressions++
+		}
+	}
+
+	if regressions != 0 {
+		return errors.Errorf("%d regressions", regressions)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+// Command hangulize is the command-line interface of Hangulize.
+//
+//   $ hangulize diff old.hgl new.hgl corpus.txt
+//   $ hangulize golden [-update] [-history FILE] testdata/golden testdata/baseline.tsv
+//   $ hangulize eval testdata/golden
+//
+// Every bundled phonemizer is imported. So the languages which require a
+// phonemizer, such as jpn or chi, are measured phonemized.
+//
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hangulize/hangulize"
+	"github.com/hangulize/hangulize/phonemize/furigana"
+	"github.com/hangulize/hangulize/phonemize/jyutping"
+	"github.com/hangulize/hangulize/phonemize/pinyin"
+)
+
+func init() {
+	hangulize.UsePhonemizer(&furigana.P)
+	hangulize.UsePhonemizer(&pinyin.P)
+	hangulize.UsePhonemizer(&jyutping.P)
+}
+
+type command struct {
+	usage string
+	run   func(args []string) error
+}
+
+var commands = map[string]command{
+	"diff":   {"diff OLD.hgl NEW.hgl CORPUS", runDiff},
+	"golden": {"golden [-update] [-history FILE] DIR BASELINE", runGolden},
+	"eval":   {"eval [-n N] DIR", runEval},
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage:")
+	for _, name := range []string{"diff", "golden", "eval"} {
+		fmt.Fprintf(os.Stderr, "  hangulize %s\n", commands[name].usage)
+	}
+	os.Exit(2)
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		usage()
+	}
+
+	if err := cmd.run(os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "hangulize %s: %s\n", os.Args[1], err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,104 @@
+package hangulize
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// WordDiff is a word transcribed differently by 2 Hangulizers.
+type WordDiff struct {
+	Word string
+	Old  string
+	New  string
+
+	// The first traces which diverge. One of them is nil if the Hangulizer
+	// has no more traces.
+	OldTrace *Trace
+	NewTrace *Trace
+}
+
+// Step returns the pipeline step where the transcriptions diverge first.
+func (d *WordDiff) Step() string {
+	if d.OldTrace != nil {
+		return d.OldTrace.Step
+	}
+	if d.NewTrace != nil {
+		return d.NewTrace.Step
+	}
+	return ""
+}
+
+// Diff transcribes the words by 2 Hangulizers and reports the words which
+// have been transcribed differently. It is useful to review a change of a
+// spec:
+//
+//   oldH := NewHangulizer(oldSpec)
+//   newH := NewHangulizer(newSpec)
+//
+//   for _, d := range Diff(oldH, newH, words) {
+//     fmt.Println(d.Word, d.Old, d.New, d.Step())
+//   }
+//
+func Diff(oldH, newH *Hangulizer, words []string) []WordDiff {
+	var diffs []WordDiff
+
+	for _, word := range words {
+		oldResult, oldTraces := oldH.HangulizeTrace(word)
+		newResult, newTraces := newH.HangulizeTrace(word)
+
+		if oldResult == newResult {
+			continue
+		}
+
+		d := WordDiff{Word: word, Old: oldResult, New: newResult}
+		d.OldTrace, d.NewTrace = divergeTraces(oldTraces, newTraces)
+
+		diffs = append(diffs, d)
+	}
+
+	return diffs
+}
+
+// divergeTraces finds the first different traces.
+func divergeTraces(oldTraces, newTraces []Trace) (*Trace, *Trace) {
+	for i := 0; i < len(oldTraces) || i < len(newTraces); i++ {
+		var oldTrace, newTrace *Trace
+
+		if i < len(oldTraces) {
+			oldTrace = &oldTraces[i]
+		}
+		if i < len(newTraces) {
+			newTrace = &newTraces[i]
+		}
+
+		if oldTrace == nil || newTrace == nil || *oldTrace != *newTrace {
+			return oldTrace, newTrace
+		}
+	}
+	return nil, nil
+}
+
+// ReadWords reads a word list. Each line is a word. If a line has tabs, only
+// the first column is the word. Empty lines and lines starting with "#" are
+// skipped.
+func ReadWords(r io.Reader) ([]string, error) {
+	var words []string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		word := strings.SplitN(line, "\t", 2)[0]
+		words = append(words, word)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return words, nil
+}
@@ -0,0 +1,66 @@
+package hangulize
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	oldSpec := mustParseSpec(`
+	transcribe:
+		"a" -> "ㅏ"
+		"b" -> "ㅂ"
+	`)
+	newSpec := mustParseSpec(`
+	transcribe:
+		"a" -> "ㅏ"
+		"b" -> "ㅃ"
+	`)
+
+	diffs := Diff(NewHangulizer(oldSpec), NewHangulizer(newSpec), []string{"a", "ab", "ba"})
+
+	assert.Len(t, diffs, 2)
+
+	assert.Equal(t, "ab", diffs[0].Word)
+	assert.Equal(t, "아브", diffs[0].Old)
+	assert.Equal(t, "아쁘", diffs[0].New)
+	assert.Equal(t, "transcribe", diffs[0].Step())
+	assert.Equal(t, oldSpec.Transcribe[1].String(), diffs[0].OldTrace.Why)
+	assert.Equal(t, newSpec.Transcribe[1].String(), diffs[0].NewTrace.Why)
+
+	assert.Equal(t, "ba", diffs[1].Word)
+}
+
+// The example of "hangulize diff" in the README. deu-new.hgl replaces
+// "ch" -> "x" with "ch" -> "k".
+func TestDiffDeu(t *testing.T) {
+	oldSpec := loadSpec("deu")
+	newSpec, err := ParseSpec(strings.NewReader(strings.Replace(oldSpec.Source,
+		`"ch"                                   -> "x"`,
+		`"ch"                                   -> "k"`, 1)))
+	assert.NoError(t, err)
+
+	words := []string{"München", "Berlin", "Hamburg"}
+	diffs := Diff(NewHangulizer(oldSpec), NewHangulizer(newSpec), words)
+
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "München", diffs[0].Word)
+	assert.Equal(t, "뮌헨", diffs[0].Old)
+	assert.Equal(t, "뮝켄", diffs[0].New)
+	assert.Equal(t, `[rewrite] "mynxen" "ch" -> "x"`, diffs[0].OldTrace.String())
+	assert.Equal(t, `[rewrite] "mynken" "ch" -> "k"`, diffs[0].NewTrace.String())
+}
+
+func TestReadWords(t *testing.T) {
+	words, err := ReadWords(strings.NewReader(`
+# comment
+Bach
+Mozart	모차르트
+
+Händel
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Bach", "Mozart", "Händel"}, words)
+}
@@ -79,6 +79,18 @@ Then write about yourself and the stage of this spec:
 	    author = "John Doe <john@example.com>"
 	    stage  = "draft"
 
+The stage is one of "draft", "beta" and "stable". Optionally, the config can
+also have the version, the license, the references to the official rules and
+the date when the spec has been reviewed last:
+
+	config:
+	    author     = "John Doe <john@example.com>"
+	    stage      = "beta"
+	    version    = "1.0"
+	    license    = "MIT"
+	    references = "https://kornorms.korean.go.kr/"
+	    reviewed   = "2018-05-01"
+
 We will write many patterns in rewrite/transcribe rules soon. Some expressions
 may appear many times annoyingly. To not repeat ourselves, we can use
 variables and macros.
@@ -121,6 +133,60 @@ won't become as the input for the next rules:
 	    "f" -> "ㅍ"
 	    "g" -> "ㄱ"
 
+A rule can have flags after the RPattern. "initial", "final" and "whole"
+restrict the match to the start, the end or the whole of a word. "once"
+replaces only the first match in a word:
+
+	rewrite:
+	    "ch" -> "k", "initial", "once"
+
+Small variations of a language, such as an old orthography, don't need
+separate specs. Declare a feature and flag the rules with "+feature" to work
+only with the feature or "-feature" to work only without it. The feature is
+enabled by the WithFeature option. For example, the "archaic" feature of nld
+reads y as ij in the old spelling:
+
+	features:
+	    "archaic" = "The old spelling with y for ij, such as Nymegen"
+
+	rewrite:
+	    "{~@}y" -> "ij", "+archaic"
+	    "y"     -> "i%"
+
+The WithWordClass option tells whether the words are person names, place
+names or common nouns. It enables the feature named after the class, such as
+"person" or "place", and passes the hint to the phonemizer. So the furigana
+phonemizer splits a Japanese full name only when it is a person name.
+
+The composed syllables can be checked by the Korean loanword orthography
+(외래어 표기법). It allows only 7 finals and forbids tense initials by default.
+The "correct" mode replaces violations with the nearest allowed Jamo while
+the "flag" mode just traces them:
+
+	orthography:
+	    mode   = "correct"
+	    finals = "ㄱ", "ㄴ", "ㄹ", "ㅁ", "ㅂ", "ㅅ", "ㅇ"
+	    tense  = "ㄲ", "ㄸ", "ㅃ"
+
+A variant spec can extend a base spec instead of copying it. It inherits the
+variables, macros, normalization, orthography and rules from the base spec:
+
+	lang:
+	    id      = "kat-2"
+	    codes   = "ka", "kat"
+	    extends = "kat-1"
+
+A rule in the variant spec overrides the base rule with the same pattern.
+The other rules are inserted after the overridden rule above them, or
+prepended if there's no such rule. The inherited rules follow the variables
+of the variant spec.
+
+A rule with the "drop" flag removes the base rule with the same pattern
+instead of overriding it. Its RPattern is ignored:
+
+	rewrite:
+	    "ჩ{ვ}" -> "", "drop"
+
 Finally, we should write expected transcription examples. They are used for
 unit testing. Verify your spec yourself:
 
@@ -0,0 +1,113 @@
+package eval
+
+import "github.com/hangulize/hangulize"
+
+// syllables splits a word into characters.
+func syllables(word string) []string {
+	var units []string
+	for _, ch := range word {
+		units = append(units, string(ch))
+	}
+	return units
+}
+
+// jamo splits a word into Jamo by DecomposeHangul. A tail keeps the hyphen
+// prefix to be distinguished from a lead:
+//
+//   한글 -> ㅎ ㅏ -ㄴ ㄱ ㅡ -ㄹ
+//
+func jamo(word string) []string {
+	var units []string
+	tail := false
+
+	for _, ch := range hangulize.DecomposeHangul(word) {
+		if ch == '-' {
+			tail = true
+			continue
+		}
+		if tail {
+			units = append(units, "-"+string(ch))
+			tail = false
+			continue
+		}
+		units = append(units, string(ch))
+	}
+
+	return units
+}
+
+// substitution is a unit replaced by another one in an alignment.
+type substitution struct {
+	expected string
+	got      string
+}
+
+// align calculates the Levenshtein distance between 2 unit sequences. It
+// returns the substitutions in the optimal alignment too.
+func align(got, expected []string) (int, []substitution) {
+	// d[i][j] is the distance between got[:i] and expected[:j].
+	d := make([][]int, len(got)+1)
+	for i := range d {
+		d[i] = make([]int, len(expected)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(got); i++ {
+		for j := 1; j <= len(expected); j++ {
+			cost := 1
+			if got[i-1] == expected[j-1] {
+				cost = 0
+			}
+
+			d[i][j] = minInt(
+				d[i-1][j-1]+cost,
+				d[i-1][j]+1,
+				d[i][j-1]+1,
+			)
+		}
+	}
+
+	// Backtrace to find the substitutions. Prefer the diagonal.
+	var subs []substitution
+
+	i, j := len(got), len(expected)
+	for i > 0 && j > 0 {
+		cost := 1
+		if got[i-1] == expected[j-1] {
+			cost = 0
+		}
+
+		switch d[i][j] {
+		case d[i-1][j-1] + cost:
+			if cost != 0 {
+				subs = append(subs, substitution{expected[j-1], got[i-1]})
+			}
+			i--
+			j--
+		case d[i-1][j] + 1:
+			i--
+		default:
+			j--
+		}
+	}
+
+	// Reverse to the order in the word.
+	for l, r := 0, len(subs)-1; l < r; l, r = l+1, r-1 {
+		subs[l], subs[r] = subs[r], subs[l]
+	}
+
+	return d[len(got)][len(expected)], subs
+}
+
+func minInt(n int, ns ...int) int {
+	for _, m := range ns {
+		if m < n {
+			n = m
+		}
+	}
+	return n
+}
+
@@ -0,0 +1,237 @@
+/*
+Package eval measures how close Hangulize transcribes to the reference
+transcriptions.
+
+Exact-match accuracy hides near misses. So it also calculates the character
+error rate (CER) at 2 levels: syllables and Jamo. "바흐" for "바크" is 1 error
+of 2 syllables but also 1 error of 4 Jamo.
+
+The substituted Jamo are counted as confusion pairs, such as "ㅗ -> ㅓ". The
+most frequent ones guide where to fix a spec:
+
+	e := eval.New()
+	e.Run(h, pairs)
+	e.Report(os.Stdout, 10)
+*/
+package eval
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/hangulize/hangulize"
+	"github.com/hangulize/hangulize/golden"
+)
+
+// Score accumulates the errors of many words.
+type Score struct {
+	Words int // The number of the evaluated words.
+	Exact int // The number of the words exactly matched.
+
+	SyllableErrors int
+	Syllables      int // The number of the syllables in the references.
+
+	JamoErrors int
+	Jamo       int // The number of the Jamo in the references.
+}
+
+// Accuracy returns the ratio of the exactly matched words.
+func (s *Score) Accuracy() float64 {
+	return ratio(s.Exact, s.Words)
+}
+
+// SyllableCER returns the character error rate at the syllable level.
+func (s *Score) SyllableCER() float64 {
+	return ratio(s.SyllableErrors, s.Syllables)
+}
+
+// JamoCER returns the character error rate at the Jamo level.
+func (s *Score) JamoCER() float64 {
+	return ratio(s.JamoErrors, s.Jamo)
+}
+
+func (s *Score) String() string {
+	return fmt.Sprintf("acc=%.2f%% syllable-cer=%.2f%% jamo-cer=%.2f%% (%d words)",
+		s.Accuracy()*100, s.SyllableCER()*100, s.JamoCER()*100, s.Words)
+}
+
+func (s *Score) add(other Score) {
+	s.Words += other.Words
+	s.Exact += other.Exact
+	s.SyllableErrors += other.SyllableErrors
+	s.Syllables += other.Syllables
+	s.JamoErrors += other.JamoErrors
+	s.Jamo += other.Jamo
+}
+
+func ratio(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) / float64(total)
+}
+
+// -----------------------------------------------------------------------------
+
+// Confusion is a Jamo transcribed as another one. A tail has the hyphen
+// prefix like "-ㄴ".
+type Confusion struct {
+	Expected string
+	Got      string
+	Count    int
+}
+
+func (c *Confusion) String() string {
+	return fmt.Sprintf("%s -> %s (%d)", c.Expected, c.Got, c.Count)
+}
+
+// Evaluator aggregates the scores by the language and the stage of the spec.
+type Evaluator struct {
+	langs      map[string]*Score
+	stages     map[hangulize.Stage]*Score
+	total      Score
+	confusions map[substitution]int
+}
+
+// New creates an Evaluator.
+func New() *Evaluator {
+	return &Evaluator{
+		langs:      make(map[string]*Score),
+		stages:     make(map[hangulize.Stage]*Score),
+		confusions: make(map[substitution]int),
+	}
+}
+
+// Add evaluates a transcription by a spec against the reference.
+func (e *Evaluator) Add(spec *hangulize.Spec, got, expected string) {
+	var s Score
+
+	s.Words = 1
+	if got == expected {
+		s.Exact = 1
+	}
+
+	expectedSyllables := syllables(expected)
+	s.SyllableErrors, _ = align(syllables(got), expectedSyllables)
+	s.Syllables = len(expectedSyllables)
+
+	expectedJamo := jamo(expected)
+	errs, subs := align(jamo(got), expectedJamo)
+	s.JamoErrors = errs
+	s.Jamo = len(expectedJamo)
+
+	for _, sub := range subs {
+		e.confusions[sub]++
+	}
+
+	lang := spec.Lang.ID
+	if e.langs[lang] == nil {
+		e.langs[lang] = &Score{}
+	}
+	e.langs[lang].add(s)
+
+	stage := spec.Config.Stage
+	if e.stages[stage] == nil {
+		e.stages[stage] = &Score{}
+	}
+	e.stages[stage].add(s)
+
+	e.total.add(s)
+}
+
+// Run transcribes a golden corpus by the Hangulizer and evaluates the
+// results.
+func (e *Evaluator) Run(h *hangulize.Hangulizer, pairs []golden.Pair) {
+	words := make([]string, len(pairs))
+	for i, pair := range pairs {
+		words[i] = pair.Word
+	}
+
+	results := h.HangulizeBatch(words)
+
+	for i, pair := range pairs {
+		e.Add(h.Spec(), results[i], pair.Expected)
+	}
+}
+
+// Lang returns the score of a language.
+func (e *Evaluator) Lang(lang string) Score {
+	if s, ok := e.langs[lang]; ok {
+		return *s
+	}
+	return Score{}
+}
+
+// Stage returns the score of the specs in a stage.
+func (e *Evaluator) Stage(stage hangulize.Stage) Score {
+	if s, ok := e.stages[stage]; ok {
+		return *s
+	}
+	return Score{}
+}
+
+// Total returns the score of every word.
+func (e *Evaluator) Total() Score {
+	return e.total
+}
+
+// Confusions returns the n most frequent confusion pairs. n < 0 means all.
+func (e *Evaluator) Confusions(n int) []Confusion {
+	var confusions []Confusion
+	for sub, count := range e.confusions {
+		confusions = append(confusions, Confusion{sub.expected, sub.got, count})
+	}
+
+	sort.Slice(confusions, func(i, j int) bool {
+		a, b := confusions[i], confusions[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if a.Expected != b.Expected {
+			return a.Expected < b.Expected
+		}
+		return a.Got < b.Got
+	})
+
+	if n >= 0 && n < len(confusions) {
+		confusions = confusions[:n]
+	}
+	return confusions
+}
+
+// Report prints the scores by the language and the stage, and the n most
+// frequent confusion pairs.
+func (e *Evaluator) Report(w io.Writer, n int) {
+	var langs []string
+	for lang := range e.langs {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+
+	for _, lang := range langs {
+		fmt.Fprintf(w, "%-8s %s\n", lang, e.langs[lang])
+	}
+	fmt.Fprintln(w)
+
+	for _, stage := range []hangulize.Stage{
+		hangulize.StageDraft,
+		hangulize.StageBeta,
+		hangulize.StageStable,
+	} {
+		if s, ok := e.stages[stage]; ok {
+			fmt.Fprintf(w, "%-8s %s\n", stage, s)
+		}
+	}
+	fmt.Fprintf(w, "%-8s %s\n", "total", &e.total)
+
+	confusions := e.Confusions(n)
+	if len(confusions) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w)
+	for _, c := range confusions {
+		fmt.Fprintln(w, &c)
+	}
+}
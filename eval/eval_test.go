@@ -0,0 +1,87 @@
+package eval
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hangulize/hangulize"
+	"github.com/hangulize/hangulize/golden"
+)
+
+func TestJamo(t *testing.T) {
+	assert.Equal(t, []string{"ㅎ", "ㅏ", "-ㄴ", "ㄱ", "ㅡ", "-ㄹ"}, jamo("한글"))
+	assert.Equal(t, []string{"ㅇ", "ㅏ", " ", "a"}, jamo("아 a"))
+}
+
+func TestAlign(t *testing.T) {
+	n, subs := align(jamo("바흐"), jamo("바크"))
+	assert.Equal(t, 1, n)
+	assert.Equal(t, []substitution{{"ㅋ", "ㅎ"}}, subs)
+
+	// Insertions and deletions are not substitutions.
+	n, subs = align(syllables("카푸치노"), syllables("카프치노스"))
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []substitution{{"프", "푸"}}, subs)
+
+	n, subs = align(nil, syllables("노"))
+	assert.Equal(t, 1, n)
+	assert.Empty(t, subs)
+}
+
+func TestEvaluator(t *testing.T) {
+	spec := &hangulize.Spec{}
+	spec.Lang.ID = "xxx"
+	spec.Config.Stage = hangulize.StageBeta
+
+	e := New()
+	e.Add(spec, "바흐", "바흐")
+	e.Add(spec, "보흐", "바흐")
+	e.Add(spec, "모차", "모차르트")
+
+	s := e.Lang("xxx")
+	assert.Equal(t, 3, s.Words)
+	assert.Equal(t, 1, s.Exact)
+
+	// 0 + 1 + 2 of 2 + 2 + 4 syllables
+	assert.Equal(t, 3, s.SyllableErrors)
+	assert.Equal(t, 8, s.Syllables)
+	assert.Equal(t, 3.0/8, s.SyllableCER())
+
+	// 0 + 1 + 4 of 4 + 4 + 8 Jamo
+	assert.Equal(t, 5, s.JamoErrors)
+	assert.Equal(t, 16, s.Jamo)
+
+	assert.Equal(t, s, e.Stage(hangulize.StageBeta))
+	assert.Equal(t, Score{}, e.Stage(hangulize.StageStable))
+	assert.Equal(t, s, e.Total())
+
+	assert.Equal(t, []Confusion{{"ㅏ", "ㅗ", 1}}, e.Confusions(-1))
+}
+
+func TestRun(t *testing.T) {
+	spec, _ := hangulize.LoadSpec("ita")
+	h := hangulize.NewHangulizer(spec)
+
+	e := New()
+	e.Run(h, []golden.Pair{
+		{Word: "Cappuccino", Expected: "카푸치노"},
+		{Word: "Roma", Expected: "로머"},
+	})
+
+	s := e.Lang("ita")
+	assert.Equal(t, 2, s.Words)
+	assert.Equal(t, 1, s.Exact)
+	assert.Equal(t, []Confusion{{"ㅓ", "ㅏ", 1}}, e.Confusions(10))
+
+	var buf bytes.Buffer
+	e.Report(&buf, 10)
+	assert.Equal(t, `ita      acc=50.00% syllable-cer=16.67% jamo-cer=8.33% (2 words)
+
+draft    acc=50.00% syllable-cer=16.67% jamo-cer=8.33% (2 words)
+total    acc=50.00% syllable-cer=16.67% jamo-cer=8.33% (2 words)
+
+ㅓ -> ㅏ (1)
+`, buf.String())
+}
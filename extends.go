@@ -0,0 +1,158 @@
+package hangulize
+
+import (
+	"sort"
+
+	"github.com/hangulize/hgl"
+	"github.com/pkg/errors"
+)
+
+// SpecLoader finds a spec by the language ID. LoadSpec is the SpecLoader for
+// the bundled specs.
+type SpecLoader func(lang string) (*Spec, bool)
+
+// inheritMacros merges the "macros" or "features" section of a base spec and
+// a variant spec. The variant's one wins.
+func inheritMacros(base, macros map[string]string) map[string]string {
+	if base == nil {
+		return macros
+	}
+
+	merged := make(map[string]string, len(base)+len(macros))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range macros {
+		merged[k] = v
+	}
+	return merged
+}
+
+// inheritDict merges the "vars" or "normalize" section of a base spec and a
+// variant spec. The variant's one wins.
+func inheritDict(base, dict map[string][]string) map[string][]string {
+	if base == nil {
+		return dict
+	}
+
+	merged := make(map[string][]string, len(base)+len(dict))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range dict {
+		merged[k] = v
+	}
+	return merged
+}
+
+// inheritRules merges the rule pairs of a base spec and a variant spec. The
+// merged pairs are compiled together with the merged vars and macros. So a
+// variant which overrides a var changes the inherited rules too.
+//
+// A variant rule with the same pattern and feature flags as a base rule
+// overrides the base rule in place. Then the base rule becomes an anchor. The
+// other variant rules are inserted after the last anchor above them, or
+// prepended if there's no anchor:
+//
+//   base:    "a" -> "1", "b" -> "2", "c" -> "3"
+//   variant: "x" -> "0", "b" -> "B", "y" -> "9"
+//   merged:  "x" -> "0", "a" -> "1", "b" -> "B", "y" -> "9", "c" -> "3"
+//
+// So a variant rule can be appended by following an anchor on the last base
+// rule.
+//
+// A variant rule with the "drop" flag removes the base rule instead. Its
+// RPattern is ignored. The dropped rule is still an anchor:
+//
+//   base:    "a" -> "1", "b" -> "2", "c" -> "3"
+//   variant: "b" -> "", "drop"
+//            "y" -> "9"
+//   merged:  "a" -> "1", "y" -> "9", "c" -> "3"
+//
+// It fails if there's no base rule to drop.
+//
+func inheritRules(base, pairs []hgl.Pair) ([]hgl.Pair, error) {
+	overridden := make([]hgl.Pair, len(base))
+	copy(overridden, base)
+
+	index := make(map[string]int, len(base))
+	for i := len(base) - 1; i >= 0; i-- {
+		index[pairKey(base[i])] = i
+	}
+
+	dropped := make(map[int]bool)
+
+	// The inserted pairs by the anchor. -1 is for the prepended pairs.
+	inserts := make(map[int][]hgl.Pair)
+	anchor := -1
+
+	for _, pair := range pairs {
+		i, ok := index[pairKey(pair)]
+
+		if isDropPair(pair) {
+			if !ok || dropped[i] {
+				return nil, errors.Errorf("no inherited rule to drop: %#v", pair.Left())
+			}
+			dropped[i] = true
+			anchor = i
+			continue
+		}
+
+		if ok {
+			overridden[i] = pair
+			anchor = i
+			continue
+		}
+		inserts[anchor] = append(inserts[anchor], pair)
+	}
+
+	merged := make([]hgl.Pair, 0, len(base)+len(pairs))
+	merged = append(merged, inserts[-1]...)
+	for i, pair := range overridden {
+		if !dropped[i] {
+			merged = append(merged, pair)
+		}
+		merged = append(merged, inserts[i]...)
+	}
+	return merged, nil
+}
+
+// isDropPair checks whether a rule pair of a variant spec has the "drop" flag.
+func isDropPair(pair hgl.Pair) bool {
+	right := pair.Right()
+	if len(right) == 0 {
+		return false
+	}
+
+	for _, flag := range right[1:] {
+		if flag == "drop" {
+			return true
+		}
+	}
+	return false
+}
+
+// pairKey identifies a rule pair to be overridden by a variant spec. The
+// rules for different features are different.
+func pairKey(pair hgl.Pair) string {
+	var features []string
+
+	right := pair.Right()
+	if len(right) != 0 {
+		for _, flag := range right[1:] {
+			if len(flag) > 1 && (flag[0] == '+' || flag[0] == '-') {
+				features = append(features, flag)
+			}
+		}
+	}
+
+	sort.Slice(features, func(i, j int) bool {
+		return features[i][1:] < features[j][1:]
+	})
+
+	key := pair.Left()
+	for _, feature := range features {
+		key += " " + feature
+	}
+	return key
+}
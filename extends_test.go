@@ -0,0 +1,184 @@
+package hangulize
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mustParseVariant(base *Spec, hgl string) *Spec {
+	load := func(lang string) (*Spec, bool) {
+		if lang != base.Lang.ID {
+			return nil, false
+		}
+		return base, true
+	}
+
+	spec, err := ParseSpecWithLoader(strings.NewReader(hgl), load)
+	if err != nil {
+		panic(err)
+	}
+	return spec
+}
+
+var baseSpec = mustParseSpec(`
+lang:
+	id     = "base"
+	codes  = "xx", "xxx"
+	script = "latin"
+
+macros:
+	"@" = "<vowels>"
+
+vars:
+	"vowels" = "a", "e", "i", "o", "u"
+
+rewrite:
+	"a" -> "b"
+	"c" -> "d"
+
+transcribe:
+	"b" -> "ㅂ"
+	"d" -> "ㄷ"
+	"@" -> "ㅇ"
+`)
+
+func TestExtendsInherits(t *testing.T) {
+	spec := mustParseVariant(baseSpec, `
+	lang:
+		id      = "variant"
+		codes   = "xx", "xxx"
+		extends = "base"
+	`)
+
+	assert.Equal(t, "latin", spec.Lang.Script)
+	assert.Equal(t, baseSpec.Macros, spec.Macros)
+	assert.Equal(t, baseSpec.Vars, spec.Vars)
+	assert.Equal(t, baseSpec.Rewrite, spec.Rewrite)
+	assert.Equal(t, baseSpec.Transcribe, spec.Transcribe)
+
+	h := NewHangulizer(spec)
+	assert.Equal(t, "브드", h.Hangulize("ac"))
+}
+
+func TestExtendsRules(t *testing.T) {
+	spec := mustParseVariant(baseSpec, `
+	lang:
+		id      = "variant"
+		codes   = "xx", "xxx"
+		extends = "base"
+
+	vars:
+		"vowels" = "a", "e", "i", "o", "u", "y"
+
+	rewrite:
+		"x" -> "a"
+		"c" -> "b"
+		"y" -> "c"
+	`)
+
+	expected := mustParseSpec(`
+	rewrite:
+		"x" -> "a"
+		"a" -> "b"
+		"c" -> "b"
+		"y" -> "c"
+	`)
+	assert.Equal(t, len(expected.Rewrite), len(spec.Rewrite))
+	for i, rule := range expected.Rewrite {
+		assert.Equal(t, rule.String(), spec.Rewrite[i].String())
+	}
+
+	// The variant vars override the base vars.
+	assert.Equal(t, []string{"a", "e", "i", "o", "u", "y"}, spec.Vars["vowels"])
+
+	h := NewHangulizer(spec)
+	assert.Equal(t, "브브", h.Hangulize("xc"))
+}
+
+func TestExtendsDrop(t *testing.T) {
+	spec := mustParseVariant(baseSpec, `
+	lang:
+		id      = "variant"
+		codes   = "xx", "xxx"
+		extends = "base"
+
+	rewrite:
+		"a" -> "", "drop"
+		"x" -> "c"
+
+	transcribe:
+		"@" -> "", "drop"
+	`)
+
+	expected := mustParseSpec(`
+	rewrite:
+		"x" -> "c"
+		"c" -> "d"
+
+	transcribe:
+		"b" -> "ㅂ"
+		"d" -> "ㄷ"
+	`)
+	assert.Equal(t, len(expected.Rewrite), len(spec.Rewrite))
+	for i, rule := range expected.Rewrite {
+		assert.Equal(t, rule.String(), spec.Rewrite[i].String())
+	}
+	assert.Equal(t, len(expected.Transcribe), len(spec.Transcribe))
+	for i, rule := range expected.Transcribe {
+		assert.Equal(t, rule.String(), spec.Transcribe[i].String())
+	}
+
+	h := NewHangulizer(spec)
+	assert.Equal(t, "드", h.Hangulize("x"))
+	assert.Equal(t, "", h.Hangulize("a"))
+}
+
+func TestExtendsDropNothing(t *testing.T) {
+	load := func(string) (*Spec, bool) { return baseSpec, true }
+
+	_, err := ParseSpecWithLoader(strings.NewReader(`
+	lang:
+		id      = "variant"
+		codes   = "xx", "xxx"
+		extends = "base"
+
+	rewrite:
+		"x" -> "", "drop"
+	`), load)
+	assert.Error(t, err)
+
+	// A spec which extends nothing has nothing to drop.
+	_, err = ParseSpec(strings.NewReader(`
+	rewrite:
+		"x" -> "", "drop"
+	`))
+	assert.Error(t, err)
+}
+
+func TestExtendsVars(t *testing.T) {
+	spec := mustParseVariant(baseSpec, `
+	lang:
+		id      = "variant"
+		codes   = "xx", "xxx"
+		extends = "base"
+
+	vars:
+		"vowels" = "a", "e", "i", "o", "u", "y"
+	`)
+
+	// The inherited rule "@" -> "ㅇ" follows the variant vars.
+	assert.Equal(t, "", NewHangulizer(baseSpec).Hangulize("y"))
+	assert.Equal(t, "으", NewHangulizer(spec).Hangulize("y"))
+}
+
+func TestExtendsNotFound(t *testing.T) {
+	_, err := ParseSpecWithLoader(strings.NewReader(`
+	lang:
+		id      = "variant"
+		codes   = "xx", "xxx"
+		extends = "unknown"
+	`), LoadSpec)
+	assert.Error(t, err)
+}
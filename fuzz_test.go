@@ -0,0 +1,115 @@
+//go:build go1.18
+// +build go1.18
+
+package hangulize
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// Fuzz targets for the native fuzzing since Go 1.18. The seeds are the bundled
+// spec sources and their test examples. Run one of them like:
+//
+//   go test -run XXX -fuzz FuzzHangulize
+//
+
+// isSyllables reports whether the word consists of only composed Hangul
+// syllables.
+func isSyllables(word string) bool {
+	if word == "" {
+		return false
+	}
+	for _, ch := range word {
+		if ch < '가' || '힣' < ch {
+			return false
+		}
+	}
+	return true
+}
+
+func FuzzHangulize(f *testing.F) {
+	langs := ListLangs()
+
+	for i, lang := range langs {
+		for _, exm := range loadSpec(lang).Test {
+			f.Add(uint8(i), exm[0])
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, i uint8, word string) {
+		if !utf8.ValidString(word) {
+			return
+		}
+
+		lang := langs[int(i)%len(langs)]
+		h := NewHangulizer(loadSpec(lang))
+
+		// Never panics.
+		result := h.Hangulize(word)
+
+		// The Jamo notation is consumed.
+		if !strings.Contains(word, "-") && strings.Contains(result, "-") {
+			t.Errorf("%s: unconsumed hyphen: %#v -> %#v", lang, word, result)
+		}
+
+		// Pure Hangul is left as it is.
+		if isSyllables(word) && result != word {
+			t.Errorf("%s: Hangul changed: %#v -> %#v", lang, word, result)
+		}
+	})
+}
+
+func FuzzParseSpec(f *testing.F) {
+	for _, lang := range ListLangs() {
+		f.Add(loadSpec(lang).Source)
+	}
+	f.Add("rewrite:\n    \"a\" -> \"b\"\n")
+
+	f.Fuzz(func(t *testing.T, source string) {
+		if !utf8.ValidString(source) {
+			return
+		}
+
+		// Never panics.
+		spec, err := ParseSpec(strings.NewReader(source))
+		if err != nil {
+			return
+		}
+
+		// A valid spec can transcribe its own examples.
+		h := NewHangulizer(spec)
+		for _, exm := range spec.Test {
+			h.Hangulize(exm[0])
+		}
+	})
+}
+
+func FuzzComposeHangul(f *testing.F) {
+	f.Add("ㅎㅏ-ㄴㄱㅡ-ㄹ")
+	f.Add("ㅗㅈ")
+	f.Add("ㅇㅏ-ㄹ-ㄱ")
+	f.Add("하-ㄴ글ㄹㅏ이ㅈ")
+	f.Add("ㄲ-ㅏ-")
+
+	f.Fuzz(func(t *testing.T, word string) {
+		// Never panics.
+		result := ComposeHangul(word)
+
+		// Hyphens are always consumed.
+		if strings.Contains(result, "-") {
+			t.Errorf("unconsumed hyphen: %#v -> %#v", word, result)
+		}
+
+		// Composed syllables are idempotent.
+		if isSyllables(word) {
+			if result != word {
+				t.Errorf("syllables changed: %#v -> %#v", word, result)
+			}
+			if restored := ComposeHangul(DecomposeHangul(word)); restored != word {
+				t.Errorf("not restored: %#v -> %#v", word, restored)
+			}
+		}
+	})
+}
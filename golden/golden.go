@@ -0,0 +1,339 @@
+/*
+Package golden runs Hangulize over golden corpora and tracks the accuracy of
+each spec.
+
+A golden corpus is a TSV file per language, such as "deu.tsv". Each line is a
+word and the approved transcription:
+
+	# German
+	Bach	바흐
+	München	뮌헨
+
+The words which already fail are recorded in a baseline. Then only the
+regressions, which passed in the baseline but fail now, are reported. Use it
+in a test:
+
+	func TestGolden(t *testing.T) {
+		results, err := golden.RunDir("testdata/golden")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		baseline, err := golden.LoadBaseline("testdata/baseline.tsv")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		golden.Check(t, results, baseline)
+	}
+
+Or use the CLI:
+
+	$ hangulize golden testdata/golden testdata/baseline.tsv
+*/
+package golden
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/hangulize/hangulize"
+)
+
+// Ext is the extension of golden corpus files.
+const Ext = ".tsv"
+
+// Pair is a word and the approved transcription.
+type Pair struct {
+	Word     string
+	Expected string
+}
+
+// Read reads a golden corpus. Empty lines and lines starting with "#" are
+// skipped.
+func Read(r io.Reader) ([]Pair, error) {
+	var pairs []Pair
+
+	scanner := bufio.NewScanner(r)
+	n := 0
+	for scanner.Scan() {
+		n++
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		cols := strings.Split(line, "\t")
+		if len(cols) != 2 {
+			return nil, errors.Errorf("line %d: must be 2 columns: %#v", n, line)
+		}
+
+		pairs = append(pairs, Pair{cols[0], cols[1]})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return pairs, nil
+}
+
+// -----------------------------------------------------------------------------
+
+// Failure is a word transcribed differently from the approved one.
+type Failure struct {
+	Word     string
+	Expected string
+	Got      string
+}
+
+// Result is the result of a golden corpus of a language.
+type Result struct {
+	Lang     string
+	Total    int
+	Failures []Failure
+}
+
+// Passed returns the number of the words transcribed as approved.
+func (r *Result) Passed() int {
+	return r.Total - len(r.Failures)
+}
+
+// Accuracy returns the ratio of the passed words.
+func (r *Result) Accuracy() float64 {
+	if r.Total == 0 {
+		return 0
+	}
+	return float64(r.Passed()) / float64(r.Total)
+}
+
+func (r *Result) String() string {
+	return fmt.Sprintf("%s: %d/%d (%.2f%%)",
+		r.Lang, r.Passed(), r.Total, r.Accuracy()*100)
+}
+
+// Run transcribes the golden corpus by the Hangulizer.
+func Run(h *hangulize.Hangulizer, pairs []Pair) *Result {
+	words := make([]string, len(pairs))
+	for i, pair := range pairs {
+		words[i] = pair.Word
+	}
+
+	results := h.HangulizeBatch(words)
+
+	r := Result{Lang: h.Spec().Lang.ID, Total: len(pairs)}
+
+	for i, pair := range pairs {
+		if results[i] != pair.Expected {
+			r.Failures = append(r.Failures, Failure{pair.Word, pair.Expected, results[i]})
+		}
+	}
+
+	return &r
+}
+
+// RunDir runs every golden corpus in the directory by the bundled spec named
+// after the file. The results are sorted by the language.
+func RunDir(dir string) ([]*Result, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+Ext))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	var results []*Result
+
+	for _, path := range paths {
+		lang := strings.TrimSuffix(filepath.Base(path), Ext)
+
+		h, err := NewHangulizer(lang)
+		if err != nil {
+			return nil, err
+		}
+
+		pairs, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, Run(h, pairs))
+	}
+
+	return results, nil
+}
+
+// NewHangulizer creates a Hangulizer for a bundled spec. It fails if the
+// phonemizer which the spec requires is not imported by UsePhonemizer.
+// Otherwise, the words would be measured unphonemized.
+func NewHangulizer(lang string) (*hangulize.Hangulizer, error) {
+	spec, ok := hangulize.LoadSpec(lang)
+	if !ok {
+		return nil, errors.Errorf("spec not found: %#v", lang)
+	}
+
+	if id := spec.Lang.Phonemizer; id != "" {
+		if _, ok := hangulize.GetPhonemizer(id); !ok {
+			return nil, errors.Wrapf(hangulize.ErrPhonemizerMissing, "%s requires %#v", lang, id)
+		}
+	}
+
+	return hangulize.NewHangulizer(spec), nil
+}
+
+// Load reads a golden corpus file.
+func Load(path string) ([]Pair, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	pairs, err := Read(f)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", path)
+	}
+	return pairs, nil
+}
+
+// -----------------------------------------------------------------------------
+
+// Baseline is the known failing words by the language.
+type Baseline map[string]map[string]bool
+
+// NewBaseline records the failing words in the results.
+func NewBaseline(results []*Result) Baseline {
+	b := make(Baseline)
+
+	for _, r := range results {
+		b[r.Lang] = make(map[string]bool)
+
+		for _, f := range r.Failures {
+			b[r.Lang][f.Word] = true
+		}
+	}
+
+	return b
+}
+
+// ReadBaseline reads a baseline. Each line is a language and a failing word
+// separated by a tab.
+func ReadBaseline(r io.Reader) (Baseline, error) {
+	b := make(Baseline)
+
+	scanner := bufio.NewScanner(r)
+	n := 0
+	for scanner.Scan() {
+		n++
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		cols := strings.Split(line, "\t")
+		if len(cols) != 2 {
+			return nil, errors.Errorf("line %d: must be 2 columns: %#v", n, line)
+		}
+
+		lang, word := cols[0], cols[1]
+		if b[lang] == nil {
+			b[lang] = make(map[string]bool)
+		}
+		b[lang][word] = true
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// LoadBaseline reads a baseline file. A missing file is an empty baseline.
+func LoadBaseline(path string) (Baseline, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return make(Baseline), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadBaseline(f)
+}
+
+// Write writes the baseline in the format ReadBaseline reads.
+func (b Baseline) Write(w io.Writer) error {
+	var langs []string
+	for lang := range b {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+
+	bw := bufio.NewWriter(w)
+
+	for _, lang := range langs {
+		var words []string
+		for word := range b[lang] {
+			words = append(words, word)
+		}
+		sort.Strings(words)
+
+		for _, word := range words {
+			fmt.Fprintf(bw, "%s\t%s\n", lang, word)
+		}
+	}
+
+	return bw.Flush()
+}
+
+// Save writes the baseline to a file.
+func (b Baseline) Save(path string) error {
+	var buf bytes.Buffer
+	if err := b.Write(&buf); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, buf.Bytes(), 0644)
+}
+
+// Regressions returns the failures which are not in the baseline.
+func (b Baseline) Regressions(r *Result) []Failure {
+	var regressions []Failure
+
+	for _, f := range r.Failures {
+		if !b[r.Lang][f.Word] {
+			regressions = append(regressions, f)
+		}
+	}
+
+	return regressions
+}
+
+// -----------------------------------------------------------------------------
+
+// T is the subset of testing.TB which Check uses.
+type T interface {
+	Errorf(format string, args ...interface{})
+	Logf(format string, args ...interface{})
+}
+
+// Check reports the regressions of the results as test errors. The accuracy
+// of each language is logged.
+func Check(t T, results []*Result, baseline Baseline) {
+	for _, r := range results {
+		t.Logf("%s", r)
+
+		for _, f := range baseline.Regressions(r) {
+			t.Errorf("%s: %s: expected %#v but got %#v", r.Lang, f.Word, f.Expected, f.Got)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package golden
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hangulize/hangulize"
+)
+
+func TestRead(t *testing.T) {
+	pairs, err := Read(strings.NewReader("# comment\nBach\t바흐\n\nRoma\t로마\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, []Pair{{"Bach", "바흐"}, {"Roma", "로마"}}, pairs)
+
+	_, err = Read(strings.NewReader("Bach\n"))
+	assert.Error(t, err)
+}
+
+func TestRunDir(t *testing.T) {
+	results, err := RunDir("testdata")
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+
+	r := results[0]
+	assert.Equal(t, "ita", r.Lang)
+	assert.Equal(t, 3, r.Total)
+	assert.Equal(t, 2, r.Passed())
+	assert.Equal(t, []Failure{{"Milano", "밀라노X", "밀라노"}}, r.Failures)
+	assert.Equal(t, "ita: 2/3 (66.67%)", r.String())
+}
+
+type recorder struct {
+	errors []string
+}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Logf(format string, args ...interface{}) {}
+
+func TestCheck(t *testing.T) {
+	results, _ := RunDir("testdata")
+
+	// Without baseline, every failure is a regression.
+	var rec recorder
+	Check(&rec, results, make(Baseline))
+	assert.Equal(t, []string{`ita: Milano: expected "밀라노X" but got "밀라노"`}, rec.errors)
+
+	// The known failure is not a regression.
+	rec = recorder{}
+	Check(&rec, results, NewBaseline(results))
+	assert.Empty(t, rec.errors)
+}
+
+func TestBaselineRoundTrip(t *testing.T) {
+	b := Baseline{
+		"ita": {"Milano": true, "Roma": true},
+		"deu": {"Bach": true},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, b.Write(&buf))
+	assert.Equal(t, "deu\tBach\nita\tMilano\nita\tRoma\n", buf.String())
+
+	b2, err := ReadBaseline(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, b, b2)
+}
+
+func TestNewHangulizerPhonemizerMissing(t *testing.T) {
+	// The furigana phonemizer is not imported in this package.
+	_, err := NewHangulizer("jpn")
+	assert.Equal(t, hangulize.ErrPhonemizerMissing, errors.Cause(err))
+
+	_, err = NewHangulizer("xxx")
+	assert.Error(t, err)
+
+	h, err := NewHangulizer("ita")
+	assert.NoError(t, err)
+	assert.Equal(t, "ita", h.Spec().Lang.ID)
+}
+
+func TestHistoryRoundTrip(t *testing.T) {
+	results, _ := RunDir("testdata")
+	at := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+	records := NewRecords(results, at)
+
+	assert.Equal(t, []Record{{at, "ita", 2, 3}}, records)
+
+	var buf bytes.Buffer
+	assert.NoError(t, WriteHistory(&buf, records))
+	assert.Equal(t, "2018-06-01T12:00:00Z\tita\t2\t3\n", buf.String())
+
+	records2, err := ReadHistory(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, records, records2)
+
+	_, err = ReadHistory(strings.NewReader("2018-06-01\tita\t2\t3\n"))
+	assert.Error(t, err)
+}
+
+func TestAppendHistory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "golden")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "history.tsv")
+
+	history, err := LoadHistory(path)
+	assert.NoError(t, err)
+	assert.Empty(t, history)
+
+	day := 24 * time.Hour
+	at := time.Date(2018, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.NoError(t, AppendHistory(path, []Record{{at, "ita", 1, 3}}))
+	assert.NoError(t, AppendHistory(path, []Record{{at.Add(day), "ita", 2, 3}}))
+
+	history, err = LoadHistory(path)
+	assert.NoError(t, err)
+	assert.Len(t, history, 2)
+
+	last, ok := Last(history, "ita")
+	assert.True(t, ok)
+	assert.Equal(t, 2, last.Passed)
+	assert.InDelta(t, 0.6667, last.Accuracy(), 0.0001)
+
+	_, ok = Last(history, "deu")
+	assert.False(t, ok)
+}
@@ -0,0 +1,149 @@
+package golden
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Record is the accuracy of a language at a moment. The records are kept in
+// a history file to track the trend of the accuracy. Each line is the time in
+// RFC 3339, the language, the passed words and the total words separated by
+// tabs:
+//
+//   2018-06-01T12:00:00Z	ita	2	3
+//
+type Record struct {
+	Time   time.Time
+	Lang   string
+	Passed int
+	Total  int
+}
+
+// NewRecords records the accuracy of the results at the time.
+func NewRecords(results []*Result, t time.Time) []Record {
+	records := make([]Record, len(results))
+	for i, r := range results {
+		records[i] = Record{t, r.Lang, r.Passed(), r.Total}
+	}
+	return records
+}
+
+// Accuracy returns the ratio of the passed words.
+func (r *Record) Accuracy() float64 {
+	if r.Total == 0 {
+		return 0
+	}
+	return float64(r.Passed) / float64(r.Total)
+}
+
+// ReadHistory reads the records in a history.
+func ReadHistory(r io.Reader) ([]Record, error) {
+	var records []Record
+
+	scanner := bufio.NewScanner(r)
+	n := 0
+	for scanner.Scan() {
+		n++
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		cols := strings.Split(line, "\t")
+		if len(cols) != 4 {
+			return nil, errors.Errorf("line %d: must be 4 columns: %#v", n, line)
+		}
+
+		t, err := time.Parse(time.RFC3339, cols[0])
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", n)
+		}
+
+		passed, err := strconv.Atoi(cols[2])
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", n)
+		}
+
+		total, err := strconv.Atoi(cols[3])
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", n)
+		}
+
+		records = append(records, Record{t, cols[1], passed, total})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// WriteHistory writes the records in the format ReadHistory reads.
+func WriteHistory(w io.Writer, records []Record) error {
+	bw := bufio.NewWriter(w)
+
+	for _, r := range records {
+		fmt.Fprintf(bw, "%s\t%s\t%d\t%d\n",
+			r.Time.UTC().Format(time.RFC3339), r.Lang, r.Passed, r.Total)
+	}
+
+	return bw.Flush()
+}
+
+// LoadHistory reads a history file. A missing file is an empty history.
+func LoadHistory(path string) ([]Record, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadHistory(f)
+}
+
+// AppendHistory appends the records to a history file. The file is created
+// if it doesn't exist.
+func AppendHistory(path string, records []Record) error {
+	var buf bytes.Buffer
+	if err := WriteHistory(&buf, records); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Last finds the latest record of a language in a history.
+func Last(records []Record, lang string) (Record, bool) {
+	var last Record
+	found := false
+
+	for _, r := range records {
+		if r.Lang == lang && (!found || !r.Time.Before(last.Time)) {
+			last = r
+			found = true
+		}
+	}
+
+	return last, found
+}
@@ -0,0 +1,118 @@
+package hangulize
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+// HangulPolicy decides how a Hangulizer treats Hangul which is already in the
+// input, such as "서울" in "서울 Milano".
+//
+// Hangul in the input is never transcribed or composed again regardless of
+// the policy. It is left as it is, just like punctuations. The policy only
+// decides whether to check the result.
+type HangulPolicy int
+
+// Hangul policies.
+const (
+	// HangulPassThrough leaves Hangul untouched without any check. It is the
+	// default.
+	HangulPassThrough HangulPolicy = iota
+
+	// HangulVerify checks whether the result keeps every Hangul segment in
+	// the input. HangulizeContext fails if not.
+	HangulVerify
+
+	// HangulWarn traces Hangul segments in mixed input and the segments not
+	// kept in the result. Use HangulizeTrace to see them.
+	HangulWarn
+)
+
+// WithHangulPolicy sets the policy for Hangul in the input.
+// HangulPassThrough is the default.
+func WithHangulPolicy(policy HangulPolicy) Option {
+	return func(h *Hangulizer) {
+		h.hangulPolicy = policy
+	}
+}
+
+// isHangul checks whether the character is Hangul, including composed
+// syllables and Jamo.
+func isHangul(ch rune) bool {
+	return unicode.Is(unicode.Hangul, ch)
+}
+
+// hangulSegment is a run of Hangul in a word.
+type hangulSegment struct {
+	start int // byte offset
+	text  string
+}
+
+// hangulSegments finds the runs of Hangul in a word.
+func hangulSegments(word string) []hangulSegment {
+	var segs []hangulSegment
+
+	start := -1
+	for i, ch := range word {
+		if isHangul(ch) {
+			if start == -1 {
+				start = i
+			}
+			continue
+		}
+
+		if start != -1 {
+			segs = append(segs, hangulSegment{start, word[start:i]})
+			start = -1
+		}
+	}
+
+	if start != -1 {
+		segs = append(segs, hangulSegment{start, word[start:]})
+	}
+
+	return segs
+}
+
+// checkHangul applies the Hangul policy to the result of the pipeline. Each
+// Hangul segment in the input should appear in the result in the same order.
+func (p *pipeline) checkHangul(input, result string) error {
+	policy := p.h.hangulPolicy
+
+	if policy == HangulPassThrough {
+		return nil
+	}
+
+	segs := hangulSegments(input)
+	if len(segs) == 0 {
+		return nil
+	}
+
+	mixed := len(segs) != 1 || segs[0].text != input
+
+	offset := 0
+	for _, seg := range segs {
+		if policy == HangulWarn && mixed {
+			why := fmt.Sprintf("%#v at %d kept as Hangul", seg.text, seg.start)
+			p.tr.TraceWarning("hangul", why, input)
+		}
+
+		i := strings.Index(result[offset:], seg.text)
+		if i != -1 {
+			offset += i + len(seg.text)
+			continue
+		}
+
+		err := errors.Errorf(`hangul %#v at %d not kept`, seg.text, seg.start)
+
+		if policy == HangulVerify {
+			return err
+		}
+		p.tr.TraceWarning("hangul", err.Error(), result)
+	}
+
+	return nil
+}
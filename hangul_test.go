@@ -0,0 +1,84 @@
+package hangulize
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHangulSegments(t *testing.T) {
+	assert.Equal(t, []hangulSegment{
+		{0, "서울"},
+		{14, "ㅋㅋ"},
+	}, hangulSegments("서울 Milano ㅋㅋ"))
+
+	assert.Nil(t, hangulSegments("Milano"))
+}
+
+func TestMixedScripts(t *testing.T) {
+	assertHangulize(t, loadSpec("ita"), "서울 밀라노", "서울 Milano")
+	assertHangulize(t, loadSpec("ita"), "ㅋㅋ 로마", "ㅋㅋ Roma")
+	assertHangulize(t, loadSpec("deu"), "뮌헨과 베를린", "München과 Berlin")
+	assertHangulize(t, loadSpec("rus"), "모스크바 서울", "Москва 서울")
+	assertHangulize(t, loadSpec("jpn"), "abc아", "abcあ")
+	assertHangulize(t, loadSpec("chi"), "베이징 서울", "Beijing 서울")
+}
+
+func TestHangulUntouched(t *testing.T) {
+	// Jamo in the input are never composed with the transcribed Jamo.
+	assertHangulize(t, loadSpec("ita"), "ㅁ로마ㅏ", "ㅁRomaㅏ")
+
+	// Pure Hangul
+	for _, lang := range []string{"ita", "deu", "jpn", "chi", "vie"} {
+		assertHangulize(t, loadSpec(lang), "한글라이즈", "한글라이즈")
+	}
+}
+
+// hangulEater is a phonemizer which doesn't keep Hangul.
+type hangulEater struct{}
+
+func (hangulEater) ID() string {
+	return "ctx"
+}
+
+func (hangulEater) Phonemize(word string) string {
+	return strings.Replace(word, "서울", "a", -1)
+}
+
+func TestHangulVerify(t *testing.T) {
+	h := NewHangulizer(ctxSpec, WithHangulPolicy(HangulVerify))
+	h.UsePhonemizer(&ctxPhonemizer{})
+
+	word, err := h.HangulizeContext(context.Background(), "서울 ab")
+	assert.NoError(t, err)
+	assert.Equal(t, "서울 아브", word)
+
+	h.UnusePhonemizer("ctx")
+	h.UsePhonemizer(&hangulEater{})
+
+	word, err = h.HangulizeContext(context.Background(), "서울 ab")
+	assert.Error(t, err)
+	assert.Equal(t, "아 아브", word)
+}
+
+func TestHangulWarn(t *testing.T) {
+	h := NewHangulizer(ctxSpec, WithHangulPolicy(HangulWarn))
+	h.UsePhonemizer(&ctxPhonemizer{})
+
+	_, tr := h.HangulizeTrace("서울 ab")
+	assert.Equal(t, Trace{"hangul", `"서울" at 0 kept as Hangul`, "서울 ab"}, tr[len(tr)-1])
+
+	h.UnusePhonemizer("ctx")
+	h.UsePhonemizer(&hangulEater{})
+
+	word, tr := h.HangulizeTrace("서울 ab")
+	assert.Equal(t, "아 아브", word)
+	assert.Equal(t, "hangul", tr[len(tr)-1].Step)
+	assert.Contains(t, tr[len(tr)-1].Why, "not kept")
+
+	// Warnings don't fail.
+	_, err := h.HangulizeContext(context.Background(), "서울 ab")
+	assert.NoError(t, err)
+}
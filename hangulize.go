@@ -1,5 +1,10 @@
 package hangulize
 
+import (
+	"context"
+	"fmt"
+)
+
 // Hangulize transcribes a non-Korean word into Hangul, which is the Korean
 // alphabet.
 //
@@ -23,11 +28,81 @@ func Hangulize(lang string, word string) string {
 type Hangulizer struct {
 	spec        *Spec
 	phonemizers map[string]Phonemizer
+	cache       *lruCache
+	workers     int
+
+	phonemizeOpts map[string]interface{}
+	hangulPolicy  HangulPolicy
+	overlapPolicy OverlapPolicy
+	features      map[string]bool
+}
+
+// Option configures a Hangulizer.
+type Option func(*Hangulizer)
+
+// WithCache makes a Hangulizer remember the transcribed words up to the given
+// size. When the same word is requested again, the result comes from the
+// cache instead of the pipeline. HangulizeTrace always bypasses the cache.
+func WithCache(size int) Option {
+	return func(h *Hangulizer) {
+		h.cache = newLRUCache(size)
+	}
+}
+
+// WithPhonemizeOption sets an option for the phonemizer. It is passed to the
+// phonemizer only if it implements ContextPhonemizer. The available keys are
+// defined by each phonemizer.
+func WithPhonemizeOption(key string, value interface{}) Option {
+	return func(h *Hangulizer) {
+		if h.phonemizeOpts == nil {
+			h.phonemizeOpts = make(map[string]interface{})
+		}
+		h.phonemizeOpts[key] = value
+	}
+}
+
+// WithFeature enables a feature declared in the "features" section of the
+// spec. The rules flagged with "+feature" work only with the feature and the
+// rules flagged with "-feature" work only without it:
+//
+//   features:
+//       "archaic" = "The old spelling with y for ij, such as Nymegen"
+//
+//   rewrite:
+//       "{~@}y" -> "ij", "+archaic"
+//       "y"     -> "i%"
+//
+// It panics if the spec doesn't declare the feature. It is a mistake in the
+// code like a bad bundled spec.
+//
+func WithFeature(feature string) Option {
+	return func(h *Hangulizer) {
+		if !h.enableFeature(feature) {
+			panic(fmt.Sprintf(`undeclared feature of "%s": %#v`, h.spec.Lang.ID, feature))
+		}
+	}
+}
+
+// enableFeature enables a feature only if the spec declares it.
+func (h *Hangulizer) enableFeature(feature string) bool {
+	if _, ok := h.spec.Features[feature]; !ok {
+		return false
+	}
+
+	if h.features == nil {
+		h.features = make(map[string]bool)
+	}
+	h.features[feature] = true
+	return true
 }
 
 // NewHangulizer creates a Hangulizer for a spec.
-func NewHangulizer(spec *Spec) *Hangulizer {
-	return &Hangulizer{spec, make(map[string]Phonemizer)}
+func NewHangulizer(spec *Spec, opts ...Option) *Hangulizer {
+	h := &Hangulizer{spec: spec, phonemizers: make(map[string]Phonemizer)}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
 }
 
 // Spec returns the underlying spec.
@@ -35,14 +110,23 @@ func (h *Hangulizer) Spec() *Spec {
 	return h.spec
 }
 
-// UsePhonemizer keeps a phonemizer for ready to use.
+// UsePhonemizer keeps a phonemizer for ready to use. The cached words are
+// discarded because they might be phonemized differently.
 func (h *Hangulizer) UsePhonemizer(p Phonemizer) bool {
-	return usePhonemizer(p, &h.phonemizers)
+	ok := usePhonemizer(p, &h.phonemizers)
+	if ok {
+		h.cache.Purge()
+	}
+	return ok
 }
 
-// UnusePhonemizer discards a phonemizer.
+// UnusePhonemizer discards a phonemizer. The cached words are discarded too.
 func (h *Hangulizer) UnusePhonemizer(id string) bool {
-	return unusePhonemizer(id, &h.phonemizers)
+	ok := unusePhonemizer(id, &h.phonemizers)
+	if ok {
+		h.cache.Purge()
+	}
+	return ok
 }
 
 // GetPhonemizer returns a phonemizer by the ID.
@@ -51,19 +135,83 @@ func (h *Hangulizer) GetPhonemizer(id string) (Phonemizer, bool) {
 	return p, ok
 }
 
+// phonemizer finds the phonemizer the spec requires. The phonemizers of the
+// Hangulizer take precedence over the global ones.
+func (h *Hangulizer) phonemizer() (Phonemizer, bool) {
+	id := h.spec.Lang.Phonemizer
+	if id == "" {
+		return nil, false
+	}
+
+	if p, ok := h.GetPhonemizer(id); ok {
+		return p, true
+	}
+
+	// Fallback by the global phonemizer registry.
+	return GetPhonemizer(id)
+}
+
+// CacheStats returns the usage of the cache. It is zero if the Hangulizer
+// has been created without WithCache.
+func (h *Hangulizer) CacheStats() CacheStats {
+	return h.cache.Stats()
+}
+
 // Hangulize transcribes a loanword into Hangul.
 func (h *Hangulizer) Hangulize(word string) string {
-	p := pipeline{h, nil}
-	return p.forward(word)
+	if result, _, ok := h.cache.Get(word); ok {
+		return result
+	}
+
+	p := pipeline{h: h}
+	result, err := p.forward(word)
+
+	// Don't cache an incomplete result.
+	if cacheable(err) {
+		h.cache.Put(word, result, err)
+	}
+	return result
+}
+
+// HangulizeContext transcribes a loanword into Hangul like Hangulize. But it
+// passes the context to the phonemizer and reports the problems which
+// Hangulize ignores.
+//
+// The result is the same with Hangulize even with an error. So the caller may
+// use or discard it:
+//
+//   - the phonemizer failed or is missing: the word is left unphonemized
+//   - HangulVerify: Hangul in the input is not kept
+//   - OverlapError by OverlapStrict: the overlapping match is dropped
+//
+// Only when the context is done, the result is empty.
+//
+func (h *Hangulizer) HangulizeContext(ctx context.Context, word string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	if result, err, ok := h.cache.Get(word); ok {
+		return result, err
+	}
+
+	p := pipeline{h: h, ctx: ctx}
+	result, err := p.forward(word)
+
+	if cacheable(err) {
+		h.cache.Put(word, result, err)
+	}
+
+	return result, err
 }
 
 // HangulizeTrace transcribes a loanword into Hangul
 // and returns the traced internal events too.
 func (h *Hangulizer) HangulizeTrace(word string) (string, []Trace) {
 	var tr tracer
-	p := pipeline{h, &tr}
+	p := pipeline{h: h, tr: &tr}
 
-	word = p.forward(word)
+	word, _ = p.forward(word)
 
 	return word, tr.Traces()
 }
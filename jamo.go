@@ -1,8 +1,8 @@
 package hangulize
 
 import (
-	"bufio"
 	"bytes"
+	"fmt"
 	"strings"
 
 	"github.com/suapapa/go_hangul"
@@ -10,14 +10,170 @@ import (
 
 const none = rune(0)
 
+// Jamo which can be each part of a syllable.
+const (
+	leadJamo   = "ㄱㄲㄴㄷㄸㄹㅁㅂㅃㅅㅆㅇㅈㅉㅊㅋㅌㅍㅎ"
+	tailJamo   = "ㄱㄲㄳㄴㄵㄶㄷㄹㄺㄻㄼㄽㄾㄿㅀㅁㅂㅄㅅㅆㅇㅈㅊㅋㅌㅍㅎ"
+	medialJamo = "ㅏㅐㅑㅒㅓㅔㅕㅖㅗㅘㅙㅚㅛㅜㅝㅞㅟㅠㅡㅢㅣ"
+)
+
+// doubleTails are the double final consonants (겹받침) made of 2 Jaeum.
+var doubleTails = map[[2]rune]rune{
+	{'ㄱ', 'ㅅ'}: 'ㄳ',
+	{'ㄴ', 'ㅈ'}: 'ㄵ',
+	{'ㄴ', 'ㅎ'}: 'ㄶ',
+	{'ㄹ', 'ㄱ'}: 'ㄺ',
+	{'ㄹ', 'ㅁ'}: 'ㄻ',
+	{'ㄹ', 'ㅂ'}: 'ㄼ',
+	{'ㄹ', 'ㅅ'}: 'ㄽ',
+	{'ㄹ', 'ㅌ'}: 'ㄾ',
+	{'ㄹ', 'ㅍ'}: 'ㄿ',
+	{'ㄹ', 'ㅎ'}: 'ㅀ',
+	{'ㅂ', 'ㅅ'}: 'ㅄ',
+}
+
 // ComposeHangul converts decomposed Jamo phonemes to composed Hangul
 // syllables.
 //
 // Decomposed Jamo phonemes look like "ㅎㅏ-ㄴㄱㅡ-ㄹㄹㅏㅇㅣㅈㅡ". A Jaeum
 // after a hyphen ("-ㄴ") means that it is a Jongseong (tail).
 //
+// It fills a missing lead with "ㅇ" and a missing medial with "ㅡ". Invalid
+// Jamo, such as "ㄸ" for a tail, are dropped. Use Composer for the other
+// behaviors.
+//
 func ComposeHangul(word string) string {
-	r := bufio.NewReader(strings.NewReader(word))
+	word, _ = defaultComposer.Compose(word)
+	return word
+}
+
+// DecomposeHangul is the inverse of ComposeHangul. It converts composed Hangul
+// syllables to decomposed Jamo phonemes in the notation ComposeHangul
+// consumes:
+//
+//   한글 -> ㅎㅏ-ㄴㄱㅡ-ㄹ
+//
+// The other characters are left as they are. ComposeHangul always restores
+// the syllables decomposed by DecomposeHangul.
+//
+func DecomposeHangul(word string) string {
+	var buf bytes.Buffer
+
+	for _, ch := range word {
+		if ch < '가' || '힣' < ch {
+			buf.WriteRune(ch)
+			continue
+		}
+
+		lead, medial, tail := hangul.Split(ch)
+
+		buf.WriteRune(lead)
+		buf.WriteRune(medial)
+		if tail != none {
+			buf.WriteRune('-')
+			buf.WriteRune(tail)
+		}
+	}
+
+	return buf.String()
+}
+
+// -----------------------------------------------------------------------------
+
+// ComposeError is returned by a strict Composer for an invalid Jamo
+// sequence.
+type ComposeError struct {
+	Pos    int  // The position of the offending character in runes.
+	Char   rune // The offending character.
+	Reason string
+}
+
+func (e *ComposeError) Error() string {
+	return fmt.Sprintf("invalid jamo %#v at %d: %s", string(e.Char), e.Pos, e.Reason)
+}
+
+// Composer composes decomposed Jamo phonemes to Hangul syllables like
+// ComposeHangul but in the customized way:
+//
+//   c := NewComposer(WithStrayJamo(), WithDoubleTails())
+//   c.Compose("ㅇㅏ-ㄹ-ㄱㅈ") // "앍ㅈ"
+//
+type Composer struct {
+	lead   rune
+	medial rune
+
+	strayJamo   bool
+	doubleTails bool
+	strict      bool
+}
+
+// ComposerOption customizes a Composer.
+type ComposerOption func(*Composer)
+
+// WithLeadFiller sets the Jaeum for a syllable without a lead. "ㅇ" is the
+// default.
+func WithLeadFiller(lead rune) ComposerOption {
+	return func(c *Composer) {
+		c.lead = lead
+	}
+}
+
+// WithMedialFiller sets the Moeum for a syllable without a medial. "ㅡ" is
+// the default.
+func WithMedialFiller(medial rune) ComposerOption {
+	return func(c *Composer) {
+		c.medial = medial
+	}
+}
+
+// WithStrayJamo makes a Composer emit Jaeum without a medial as it is instead
+// of filling the medial:
+//
+//   ㅅㅡㅌ -> 스트 (default)
+//   ㅅㅡㅌ -> 스ㅌ (stray Jamo)
+//
+// Invalid Jamo are emitted as it is too instead of being dropped.
+func WithStrayJamo() ComposerOption {
+	return func(c *Composer) {
+		c.strayJamo = true
+	}
+}
+
+// WithDoubleTails makes a Composer combine 2 tails into a double final
+// consonant (겹받침) instead of making a new syllable for the second tail:
+//
+//   ㅇㅏ-ㄹ-ㄱ -> 알윽 (default)
+//   ㅇㅏ-ㄹ-ㄱ -> 앍 (double tails)
+//
+func WithDoubleTails() ComposerOption {
+	return func(c *Composer) {
+		c.doubleTails = true
+	}
+}
+
+// WithStrict makes a Composer fail with a ComposeError for an invalid Jamo
+// sequence, such as "ㄸ" for a tail or a hyphen without a Jaeum.
+func WithStrict() ComposerOption {
+	return func(c *Composer) {
+		c.strict = true
+	}
+}
+
+// NewComposer creates a Composer.
+func NewComposer(opts ...ComposerOption) *Composer {
+	c := &Composer{lead: 'ㅇ', medial: 'ㅡ'}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// defaultComposer is the Composer for ComposeHangul.
+var defaultComposer = NewComposer()
+
+// Compose converts decomposed Jamo phonemes to composed Hangul syllables. It
+// fails only in the strict mode.
+func (c *Composer) Compose(word string) (string, error) {
 	var buf bytes.Buffer
 
 	var lmt [3]rune // [lead, medial, tail]
@@ -31,49 +187,80 @@ func ComposeHangul(word string) string {
 	score := -1
 
 	isTail := false
+	hyphen := -1
 
 	writeLetter := func() {
 		if lmt[0] == none && lmt[1] == none && lmt[2] == none {
 			return
 		}
 
-		// Fill missing Jamo.
-		if lmt[0] == none {
-			lmt[0] = 'ㅇ'
-		}
-		if lmt[1] == none {
-			lmt[1] = 'ㅡ'
-		}
+		if lmt[1] == none && c.strayJamo {
+			// Emit the stray Jaeum as it is.
+			for _, ch := range lmt {
+				if ch != none {
+					buf.WriteRune(ch)
+				}
+			}
+		} else {
+			// Fill missing Jamo.
+			if lmt[0] == none {
+				lmt[0] = c.lead
+			}
+			if lmt[1] == none {
+				lmt[1] = c.medial
+			}
 
-		// Complete a letter.
-		letter := hangul.Join(lmt[0], lmt[1], lmt[2])
-		buf.WriteRune(letter)
+			// Complete a letter.
+			letter := hangul.Join(lmt[0], lmt[1], lmt[2])
+			buf.WriteRune(letter)
+		}
 
 		// Clear.
 		lmt[0], lmt[1], lmt[2] = none, none, none
 	}
 
-	for {
-		ch, _, err := r.ReadRune()
-
-		if err != nil {
-			break
+	// invalid reports an invalid character. It returns an error only in the
+	// strict mode. Otherwise, the character is emitted as a stray Jamo or
+	// dropped.
+	invalid := func(pos int, ch rune, reason string) error {
+		if c.strict {
+			return &ComposeError{pos, ch, reason}
+		}
+		if c.strayJamo {
+			writeLetter()
+			prevScore = -1
+			buf.WriteRune(ch)
 		}
+		return nil
+	}
+
+	pos := -1
+	for _, ch := range word {
+		pos++
 
 		// Hyphen is the prefix of a tail Jaeum.
 		// Perhaps the next ch is a Jaeum.
 		if ch == '-' {
+			if isTail && c.strict {
+				return "", &ComposeError{pos, ch, "hyphen without a tail"}
+			}
 			isTail = true
+			hyphen = pos
 			continue
 		}
 
 		if !hangul.IsHangul(ch) {
+			if isTail && c.strict {
+				return "", &ComposeError{hyphen, '-', "hyphen without a tail"}
+			}
+
 			if prevScore != -1 {
 				writeLetter()
 			}
 
 			buf.WriteRune(ch)
 			prevScore = -1
+			isTail = false
 			continue
 		}
 
@@ -82,6 +269,10 @@ func ComposeHangul(word string) string {
 
 		if !isJaeum && !isMoeum {
 			// Composed Hangul.
+			if isTail && c.strict {
+				return "", &ComposeError{hyphen, '-', "hyphen without a tail"}
+			}
+
 			writeLetter()
 
 			lmt[0], lmt[1], lmt[2] = hangul.Split(ch)
@@ -100,12 +291,42 @@ func ComposeHangul(word string) string {
 					score = lead
 				}
 			} else if isMoeum {
+				if isTail && c.strict {
+					return "", &ComposeError{hyphen, '-', "hyphen without a tail"}
+				}
 				score = medial
 			} else {
 				// never reaches.
 				panic("neither Jaeum nor Moeum")
 			}
 
+			isTail = false
+
+			// Validate the Jaeum for the position.
+			var reason string
+			switch {
+			case score == lead && !strings.ContainsRune(leadJamo, ch):
+				reason = "not a lead"
+			case score == tail && !strings.ContainsRune(tailJamo, ch):
+				reason = "not a tail"
+			case score == medial && !strings.ContainsRune(medialJamo, ch):
+				reason = "not a medial"
+			}
+			if reason != "" {
+				if err := invalid(pos, ch, reason); err != nil {
+					return "", err
+				}
+				continue
+			}
+
+			// Combine 2 tails.
+			if score == tail && prevScore == tail && c.doubleTails {
+				if double, ok := doubleTails[[2]rune{lmt[2], ch}]; ok {
+					lmt[2] = double
+					continue
+				}
+			}
+
 			// Write a letter.
 			if score <= prevScore {
 				writeLetter()
@@ -121,10 +342,14 @@ func ComposeHangul(word string) string {
 		isTail = false
 	}
 
+	if isTail && c.strict {
+		return "", &ComposeError{hyphen, '-', "hyphen without a tail"}
+	}
+
 	// Write the final letter.
 	if prevScore != -1 {
 		writeLetter()
 	}
 
-	return buf.String()
+	return buf.String(), nil
 }
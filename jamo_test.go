@@ -17,6 +17,84 @@ func TestComposeHangulOnComposed(t *testing.T) {
 	assert.Equal(t, "한글라이즈", ComposeHangul("하-ㄴ글ㄹㅏ이ㅈ"))
 }
 
+func TestDecomposeHangul(t *testing.T) {
+	assert.Equal(t, "ㅎㅏ-ㄴㄱㅡ-ㄹ", DecomposeHangul("한글"))
+	assert.Equal(t, "ㅇㅏ-ㄺ", DecomposeHangul("앍"))
+	assert.Equal(t, "ㅅㅓㅇㅜ-ㄹ Milano", DecomposeHangul("서울 Milano"))
+}
+
+func TestDecomposeHangulRoundTrip(t *testing.T) {
+	n := 0
+	for ch := '가'; ch <= '힣'; ch++ {
+		syllable := string(ch)
+		jamo := DecomposeHangul(syllable)
+
+		if !assert.Equal(t, syllable, ComposeHangul(jamo), jamo) {
+			return
+		}
+		n++
+	}
+	assert.Equal(t, 11172, n)
+
+	// Consecutive syllables as well.
+	word := "한글라이즈 앍읊"
+	assert.Equal(t, word, ComposeHangul(DecomposeHangul(word)))
+}
+
+func TestComposerFillers(t *testing.T) {
+	c := NewComposer(WithLeadFiller('ㅎ'), WithMedialFiller('ㅣ'))
+	word, err := c.Compose("ㅗㅈ")
+	assert.NoError(t, err)
+	assert.Equal(t, "호지", word)
+}
+
+func TestComposerStrayJamo(t *testing.T) {
+	c := NewComposer(WithStrayJamo())
+
+	word, _ := c.Compose("ㅅㅡㅌ")
+	assert.Equal(t, "스ㅌ", word)
+
+	// Invalid Jamo
+	word, _ = c.Compose("ㅇㅏ-ㄸ")
+	assert.Equal(t, "아ㄸ", word)
+
+	// Dropped by default.
+	assert.Equal(t, "아", ComposeHangul("ㅇㅏ-ㄸ"))
+}
+
+func TestComposerDoubleTails(t *testing.T) {
+	c := NewComposer(WithDoubleTails())
+
+	word, _ := c.Compose("ㅇㅏ-ㄹ-ㄱ")
+	assert.Equal(t, "앍", word)
+
+	// Not a double final consonant.
+	word, _ = c.Compose("ㅇㅏ-ㄹ-ㄴ")
+	assert.Equal(t, "알은", word)
+
+	assert.Equal(t, "알윽", ComposeHangul("ㅇㅏ-ㄹ-ㄱ"))
+}
+
+func TestComposerStrict(t *testing.T) {
+	c := NewComposer(WithStrict())
+
+	word, err := c.Compose("ㅎㅏ-ㄴㄱㅡ-ㄹ")
+	assert.NoError(t, err)
+	assert.Equal(t, "한글", word)
+
+	_, err = c.Compose("ㅇㅏ-ㄸ")
+	assert.Equal(t, &ComposeError{3, 'ㄸ', "not a tail"}, err)
+
+	_, err = c.Compose("ㄳㅏ")
+	assert.Equal(t, &ComposeError{0, 'ㄳ', "not a lead"}, err)
+
+	_, err = c.Compose("ㅇㅏ-ㅏ")
+	assert.Equal(t, &ComposeError{2, '-', "hyphen without a tail"}, err)
+
+	_, err = c.Compose("ㅇㅏ-")
+	assert.EqualError(t, err, `invalid jamo "-" at 2: hyphen without a tail`)
+}
+
 // -----------------------------------------------------------------------------
 // Benchmarks
 
@@ -38,3 +116,16 @@ func ExampleComposeHangul_interpolation() {
 	fmt.Println(ComposeHangul("ㅗㅈ"))
 	// Output: 오즈
 }
+
+func ExampleDecomposeHangul() {
+	fmt.Println(DecomposeHangul("한글라이즈"))
+	// Output: ㅎㅏ-ㄴㄱㅡ-ㄹㄹㅏㅇㅣㅈㅡ
+}
+
+func ExampleComposer() {
+	c := NewComposer(WithStrayJamo(), WithDoubleTails())
+
+	word, _ := c.Compose("ㅇㅏ-ㄹ-ㄱㅈ")
+	fmt.Println(word)
+	// Output: 앍ㅈ
+}
@@ -0,0 +1,194 @@
+package hangulize
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/suapapa/go_hangul"
+
+	"github.com/hangulize/hgl"
+)
+
+// Orthography modes.
+const (
+	// OrthographyFlag traces violations but keeps them.
+	OrthographyFlag = "flag"
+
+	// OrthographyCorrect replaces violations with the nearest allowed Jamo
+	// and traces the corrections.
+	OrthographyCorrect = "correct"
+)
+
+// Default constraints by the Korean loanword orthography (외래어 표기법).
+var (
+	defaultFinals = []string{"ㄱ", "ㄴ", "ㄹ", "ㅁ", "ㅂ", "ㅅ", "ㅇ"}
+	defaultTense  = []string{"ㄲ", "ㄸ", "ㅃ"}
+)
+
+// finalCorrections map disallowed finals to the representative ones.
+var finalCorrections = map[rune]rune{
+	'ㄲ': 'ㄱ', 'ㅋ': 'ㄱ', 'ㄳ': 'ㄱ', 'ㄺ': 'ㄱ',
+	'ㄵ': 'ㄴ', 'ㄶ': 'ㄴ',
+	'ㄷ': 'ㅅ', 'ㅅ': 'ㅅ', 'ㅆ': 'ㅅ', 'ㅈ': 'ㅅ', 'ㅊ': 'ㅅ', 'ㅌ': 'ㅅ', 'ㅎ': 'ㅅ',
+	'ㄼ': 'ㄹ', 'ㄽ': 'ㄹ', 'ㄾ': 'ㄹ', 'ㅀ': 'ㄹ',
+	'ㄻ': 'ㅁ',
+	'ㅍ': 'ㅂ', 'ㄿ': 'ㅂ', 'ㅄ': 'ㅂ',
+}
+
+// tenseCorrections map tense initials to the aspirated or plain ones.
+var tenseCorrections = map[rune]rune{
+	'ㄲ': 'ㅋ',
+	'ㄸ': 'ㅌ',
+	'ㅃ': 'ㅍ',
+	'ㅆ': 'ㅅ',
+	'ㅉ': 'ㅈ',
+}
+
+// -----------------------------------------------------------------------------
+// "orthography" section
+
+// Orthography is the constraints on the composed syllables. The Korean
+// loanword orthography allows only 7 finals and forbids tense initials in
+// most languages:
+//
+//   orthography:
+//       mode   = "correct"
+//       finals = "ㄱ", "ㄴ", "ㄹ", "ㅁ", "ㅂ", "ㅅ", "ㅇ"
+//       tense  = "ㄲ", "ㄸ", "ㅃ"
+//
+// "finals" are the allowed finals and "tense" are the forbidden initials.
+// They are the above by default. The mode is "flag" or "correct". Without the
+// section, the syllables are not checked at all.
+type Orthography struct {
+	Mode   string
+	Finals []string
+	Tense  []string
+
+	finals map[rune]bool
+	tense  map[rune]bool
+}
+
+func newOrthography(dict *hgl.DictSection) (*Orthography, error) {
+	mode := dict.One("mode")
+
+	switch mode {
+	case OrthographyFlag, OrthographyCorrect:
+	default:
+		return nil, errors.Errorf(`orthography mode must be "%s" or "%s": %#v`,
+			OrthographyFlag, OrthographyCorrect, mode)
+	}
+
+	finals := dict.All("finals")
+	if finals == nil {
+		finals = defaultFinals
+	}
+
+	tense := dict.All("tense")
+	if tense == nil {
+		tense = defaultTense
+	}
+
+	o := Orthography{Mode: mode, Finals: finals, Tense: tense}
+
+	var err error
+
+	o.finals, err = jamoSet(finals)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid orthography finals")
+	}
+
+	o.tense, err = jamoSet(tense)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid orthography tense")
+	}
+
+	return &o, nil
+}
+
+// jamoSet collects Jamo. An empty string is ignored for an empty set.
+func jamoSet(jamo []string) (map[rune]bool, error) {
+	set := make(map[rune]bool)
+	for _, j := range jamo {
+		if j == "" {
+			continue
+		}
+
+		runes := []rune(j)
+		if len(runes) != 1 || !isHangul(runes[0]) {
+			return nil, errors.Errorf("not a Jamo: %#v", j)
+		}
+
+		set[runes[0]] = true
+	}
+	return set, nil
+}
+
+// Enabled returns whether the syllables should be checked.
+func (o *Orthography) Enabled() bool {
+	return o.Mode != ""
+}
+
+// check checks the composed syllables in a word. It returns the word, which
+// is corrected in the "correct" mode, and the descriptions of the
+// violations:
+//
+//   "까 -> 카 (tense initial ㄲ)"
+//   "붘 -> 북 (final ㅋ)"
+//
+func (o *Orthography) check(word string) (string, []string) {
+	if !o.Enabled() {
+		return word, nil
+	}
+
+	var buf bytes.Buffer
+	var notes []string
+
+	correct := o.Mode == OrthographyCorrect
+
+	for _, ch := range word {
+		if ch < '가' || '힣' < ch {
+			buf.WriteRune(ch)
+			continue
+		}
+
+		lead, medial, tail := hangul.Split(ch)
+		var reasons []string
+
+		if o.tense[lead] {
+			reasons = append(reasons, fmt.Sprintf("tense initial %c", lead))
+			if to, ok := tenseCorrections[lead]; ok {
+				lead = to
+			}
+		}
+
+		if tail != none && !o.finals[tail] {
+			reasons = append(reasons, fmt.Sprintf("final %c", tail))
+			if to, ok := finalCorrections[tail]; ok && o.finals[to] {
+				tail = to
+			} else {
+				tail = none
+			}
+		}
+
+		if len(reasons) == 0 {
+			buf.WriteRune(ch)
+			continue
+		}
+
+		why := strings.Join(reasons, ", ")
+
+		if !correct {
+			buf.WriteRune(ch)
+			notes = append(notes, fmt.Sprintf("%c (%s)", ch, why))
+			continue
+		}
+
+		fixed := hangul.Join(lead, medial, tail)
+		buf.WriteRune(fixed)
+		notes = append(notes, fmt.Sprintf("%c -> %c (%s)", ch, fixed, why))
+	}
+
+	return buf.String(), notes
+}
@@ -0,0 +1,105 @@
+package hangulize
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var orthographySpec = `
+lang:
+	id    = "test"
+	codes = "", ""
+
+orthography:
+	mode = "%s"
+
+transcribe:
+	"kk" -> "ㄲ"
+	"k"  -> "-ㅋ"
+	"a"  -> "ㅏ"
+	"b"  -> "ㅂ"
+	"u"  -> "ㅜ"
+`
+
+func orthographyTestSpec(mode string) *Spec {
+	return mustParseSpec(fmt.Sprintf(orthographySpec, mode))
+}
+
+func TestOrthographyDefaults(t *testing.T) {
+	o := orthographyTestSpec("flag").Orthography
+
+	assert.True(t, o.Enabled())
+	assert.Equal(t, defaultFinals, o.Finals)
+	assert.Equal(t, defaultTense, o.Tense)
+}
+
+func TestOrthographyDisabled(t *testing.T) {
+	spec := mustParseSpec(`
+	transcribe:
+		"k" -> "-ㅋ"
+		"a" -> "ㅏ"
+	`)
+	assert.False(t, spec.Orthography.Enabled())
+	assertHangulize(t, spec, "앜", "ak")
+}
+
+func TestOrthographyFlag(t *testing.T) {
+	h := NewHangulizer(orthographyTestSpec("flag"))
+
+	word, tr := h.HangulizeTrace("buk kka")
+	assert.Equal(t, "붘 까", word)
+
+	assert.Equal(t, []Trace{
+		{"orthography", "붘 (final ㅋ)", "붘 까"},
+		{"orthography", "까 (tense initial ㄲ)", "붘 까"},
+	}, tr[len(tr)-2:])
+}
+
+func TestOrthographyCorrect(t *testing.T) {
+	h := NewHangulizer(orthographyTestSpec("correct"))
+
+	word, tr := h.HangulizeTrace("buk kka")
+	assert.Equal(t, "북 카", word)
+
+	assert.Equal(t, []Trace{
+		{"orthography", "붘 -> 북 (final ㅋ)", "북 카"},
+		{"orthography", "까 -> 카 (tense initial ㄲ)", "북 카"},
+	}, tr[len(tr)-2:])
+
+	// Hangul in the input is not corrected.
+	assert.Equal(t, "까 북", h.Hangulize("까 buk"))
+}
+
+func TestOrthographyCustom(t *testing.T) {
+	spec := mustParseSpec(`
+	orthography:
+		mode   = "correct"
+		finals = "ㄴ", "ㄹ"
+		tense  = ""
+
+	transcribe:
+		"kk" -> "ㄲ"
+		"k"  -> "-ㅋ"
+		"a"  -> "ㅏ"
+	`)
+
+	assert.Equal(t, "까아", NewHangulizer(spec).Hangulize("kkaka"))
+}
+
+func TestOrthographyInvalid(t *testing.T) {
+	_, err := ParseSpec(strings.NewReader(`
+	orthography:
+		mode = "fix"
+	`))
+	assert.Error(t, err)
+
+	_, err = ParseSpec(strings.NewReader(`
+	orthography:
+		mode   = "flag"
+		finals = "k"
+	`))
+	assert.Error(t, err)
+}
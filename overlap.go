@@ -0,0 +1,142 @@
+package hangulize
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// OverlapPolicy decides which replacement survives when matches of a rule
+// overlap each other or a transcribe rule matches a region which has already
+// been transcribed by a former rule.
+//
+// A region already transcribed always wins regardless of the policy. Every
+// overlap is traced as a warning.
+//
+// In practice, only the latter happens. A rule finds non-overlapping matches
+// by itself so the policies pick the same matches. Then they differ only in
+// whether OverlapStrict reports the error.
+type OverlapPolicy int
+
+// Overlap policies.
+const (
+	// OverlapLeftmostLongest keeps the leftmost match. Among the matches
+	// starting at the same position, the longest one wins. It is the
+	// default.
+	OverlapLeftmostLongest OverlapPolicy = iota
+
+	// OverlapFirstWins keeps the match which has been found first.
+	OverlapFirstWins
+
+	// OverlapStrict resolves overlaps like OverlapFirstWins but reports an
+	// OverlapError. Hangulize ignores the error but HangulizeContext returns
+	// it.
+	OverlapStrict
+)
+
+// WithOverlapPolicy sets the policy for overlapping matches.
+func WithOverlapPolicy(policy OverlapPolicy) Option {
+	return func(h *Hangulizer) {
+		h.overlapPolicy = policy
+	}
+}
+
+// OverlapError is returned by HangulizeContext with OverlapStrict when
+// matches overlap.
+type OverlapError struct {
+	Step string // "rewrite" or "transcribe"
+	Rule string
+	Word string
+
+	// The dropped match.
+	Start int
+	Stop  int
+}
+
+func (e *OverlapError) Error() string {
+	return fmt.Sprintf("%s: %s overlaps at [%d-%d] in %#v",
+		e.Step, e.Rule, e.Start, e.Stop, e.Word)
+}
+
+// resolveOverlaps drops the overlapping replacements by the policy. The kept
+// replacements are sorted by the position. consumed marks the bytes which
+// must not be replaced again. It may be empty.
+func resolveOverlaps(
+	repls []replacement,
+	consumed string,
+	policy OverlapPolicy,
+) (kept, dropped []replacement) {
+
+	ordered := repls
+
+	if policy == OverlapLeftmostLongest {
+		ordered = make([]replacement, len(repls))
+		copy(ordered, repls)
+
+		sort.SliceStable(ordered, func(i, j int) bool {
+			a, b := ordered[i], ordered[j]
+			if a.start != b.start {
+				return a.start < b.start
+			}
+			return a.stop-a.start > b.stop-b.start
+		})
+	}
+
+	for _, repl := range ordered {
+		ok := true
+
+		if consumed != "" && strings.Contains(consumed[repl.start:repl.stop], "\x00") {
+			ok = false
+		}
+
+		for _, k := range kept {
+			if !ok {
+				break
+			}
+			if repl.start < k.stop && k.start < repl.stop {
+				ok = false
+			}
+		}
+
+		if ok {
+			kept = append(kept, repl)
+		} else {
+			dropped = append(dropped, repl)
+		}
+	}
+
+	if dropped == nil {
+		// No overlap. Keep the original order which is already sorted.
+		return repls, nil
+	}
+
+	sort.SliceStable(kept, func(i, j int) bool {
+		return kept[i].start < kept[j].start
+	})
+	return kept, dropped
+}
+
+// resolveOverlaps drops the overlapping replacements by the policy of the
+// Hangulizer. The overlaps are traced. With OverlapStrict, the first overlap
+// is remembered as the error of the pipeline.
+func (p *pipeline) resolveOverlaps(
+	step string,
+	rule *Rule,
+	word string,
+	consumed string,
+	repls []replacement,
+) []replacement {
+
+	kept, dropped := resolveOverlaps(repls, consumed, p.h.overlapPolicy)
+
+	for _, repl := range dropped {
+		why := fmt.Sprintf("%s overlaps at [%d-%d]", rule, repl.start, repl.stop)
+		p.tr.TraceWarning(step, why, word)
+
+		if p.h.overlapPolicy == OverlapStrict && p.err == nil {
+			p.err = &OverlapError{step, rule.String(), word, repl.start, repl.stop}
+		}
+	}
+
+	return kept
+}
@@ -0,0 +1,124 @@
+package hangulize
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Overlapping replacements of "abcd":
+//
+//   [0-2] "ab"
+//   [1-4] "bcd"
+//   [1-2] "b"
+//
+var overlapping = []replacement{{0, 2, "X"}, {1, 4, "Y"}, {1, 2, "Z"}}
+
+func TestResolveOverlapsNone(t *testing.T) {
+	repls := []replacement{{0, 1, "X"}, {2, 3, "Y"}}
+	kept, dropped := resolveOverlaps(repls, "", OverlapLeftmostLongest)
+	assert.Equal(t, repls, kept)
+	assert.Nil(t, dropped)
+}
+
+func TestResolveOverlapsLeftmostLongest(t *testing.T) {
+	repls := []replacement{{1, 2, "Z"}, {1, 4, "Y"}, {0, 1, "X"}}
+	kept, dropped := resolveOverlaps(repls, "", OverlapLeftmostLongest)
+	assert.Equal(t, []replacement{{0, 1, "X"}, {1, 4, "Y"}}, kept)
+	assert.Equal(t, []replacement{{1, 2, "Z"}}, dropped)
+}
+
+func TestResolveOverlapsFirstWins(t *testing.T) {
+	repls := []replacement{{1, 2, "Z"}, {1, 4, "Y"}, {0, 1, "X"}}
+	kept, dropped := resolveOverlaps(repls, "", OverlapFirstWins)
+	assert.Equal(t, []replacement{{0, 1, "X"}, {1, 2, "Z"}}, kept)
+	assert.Equal(t, []replacement{{1, 4, "Y"}}, dropped)
+}
+
+func TestResolveOverlapsConsumed(t *testing.T) {
+	// "b" has already been transcribed.
+	kept, dropped := resolveOverlaps(overlapping, "a\x00cd", OverlapLeftmostLongest)
+	assert.Empty(t, kept)
+	assert.Equal(t, 3, len(dropped))
+
+	kept, _ = resolveOverlaps([]replacement{{2, 4, "Y"}}, "a\x00cd", OverlapLeftmostLongest)
+	assert.Equal(t, []replacement{{2, 4, "Y"}}, kept)
+}
+
+func TestOverlapStrict(t *testing.T) {
+	spec := mustParseSpec(`
+	rewrite:
+		"ab" -> "X"
+	`)
+	rule := spec.Rewrite[0]
+
+	var tr tracer
+	h := NewHangulizer(spec, WithOverlapPolicy(OverlapStrict))
+	p := pipeline{h: h, tr: &tr}
+
+	kept := p.resolveOverlaps("rewrite", rule, "abcd", "", overlapping)
+	assert.Equal(t, []replacement{{0, 2, "X"}}, kept)
+
+	assert.Equal(t, &OverlapError{"rewrite", rule.String(), "abcd", 1, 4}, p.err)
+	assert.Len(t, tr.Traces(), 2)
+
+	// Not an error by default.
+	p = pipeline{h: NewHangulizer(spec)}
+	p.resolveOverlaps("rewrite", rule, "abcd", "", overlapping)
+	assert.NoError(t, p.err)
+}
+
+// overlapSpec has a transcribe rule which may match "b" transcribed already.
+var overlapSpec = mustParseSpec(`
+transcribe:
+	"b"  -> "ㅂ"
+	"a." -> "ㅇ"
+	"a"  -> "ㅏ"
+`)
+
+func TestOverlapTranscribed(t *testing.T) {
+	rule := overlapSpec.Transcribe[1]
+
+	for _, policy := range []OverlapPolicy{OverlapLeftmostLongest, OverlapFirstWins, OverlapStrict} {
+		h := NewHangulizer(overlapSpec, WithOverlapPolicy(policy))
+
+		// "a." can't take "b" which has been transcribed already.
+		word, tr := h.HangulizeTrace("ab")
+		assert.Equal(t, "아브", word)
+		assert.Contains(t, tr, Trace{"transcribe", rule.String() + " overlaps at [0-2]", "aㅂ"})
+	}
+
+	// Only OverlapStrict reports it.
+	h := NewHangulizer(overlapSpec)
+	word, err := h.HangulizeContext(context.Background(), "ab")
+	assert.NoError(t, err)
+	assert.Equal(t, "아브", word)
+
+	h = NewHangulizer(overlapSpec, WithOverlapPolicy(OverlapStrict))
+	word, err = h.HangulizeContext(context.Background(), "ab")
+	assert.Equal(t, &OverlapError{"transcribe", rule.String(), "aㅂ", 0, 2}, err)
+	assert.Equal(t, "아브", word)
+}
+
+func TestOverlapCacheable(t *testing.T) {
+	assert.True(t, cacheable(nil))
+	assert.True(t, cacheable(&OverlapError{}))
+	assert.False(t, cacheable(context.Canceled))
+}
+
+func TestOverlapErrorCached(t *testing.T) {
+	h := NewHangulizer(mustParseSpec(``), WithCache(10))
+	overlap := &OverlapError{"rewrite", `"ab" -> "X"`, "abcd", 1, 4}
+	h.cache.Put("abcd", "XY", overlap)
+
+	// Hangulize ignores the cached error.
+	assert.Equal(t, "XY", h.Hangulize("abcd"))
+
+	// HangulizeContext reports it.
+	word, err := h.HangulizeContext(context.Background(), "abcd")
+	assert.Equal(t, overlap, err)
+	assert.Equal(t, "XY", word)
+
+	assert.Equal(t, uint64(2), h.CacheStats().Hits)
+}
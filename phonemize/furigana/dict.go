@@ -0,0 +1,147 @@
+package furigana
+
+import (
+	"strings"
+
+	kagome "github.com/ikawaha/kagome.ipadic/tokenizer"
+)
+
+// Dict is a system dictionary for the morphological analysis. IPADIC is the
+// default and the only dictionary in this package. Other dictionaries are
+// huge so they live in their own packages. Import them only if you need:
+//
+//   import "github.com/hangulize/hangulize/phonemize/furigana/unidic"
+//
+//   h.UsePhonemizer(furigana.New(furigana.WithDict(unidic.Dict)))
+//
+type Dict interface {
+	// NewAnalyzer prepares an Analyzer. It is expensive so a Phonemizer
+	// calls it only once. The user dictionary file is in the Kagome format:
+	//
+	//   surface,segmented surface,segmented reading,part-of-speech
+	//
+	// The path is empty if there's no user dictionary.
+	NewAnalyzer(userDict string) (Analyzer, error)
+}
+
+// Analyzer splits a word into morphemes. It is safe to call concurrently.
+type Analyzer interface {
+	Analyze(word string, mode Mode) []Morpheme
+}
+
+// Class tells which dictionary knows a morpheme.
+type Class int
+
+// Classes of morphemes.
+const (
+	Unknown Class = iota
+	Known
+	User
+)
+
+// Morpheme is a morpheme analyzed by a system dictionary. Each dictionary has
+// its own layout of the features. An Analyzer picks the features which the
+// typewriter needs.
+type Morpheme struct {
+	Surface string
+	Class   Class
+
+	PartOfSpeech  string
+	SubClass1     string
+	SubClass2     string
+	RootForm      string
+	Reading       string
+	Pronunciation string
+
+	// Meta is true if the morpheme is not a word, such as a symbol.
+	Meta bool
+}
+
+// IPADIC is the default system dictionary.
+var IPADIC Dict = ipadic{}
+
+type ipadic struct{}
+
+// NewAnalyzer creates an Analyzer with Kagome.
+func (ipadic) NewAnalyzer(userDict string) (Analyzer, error) {
+	k := kagome.New()
+
+	if userDict != "" {
+		udic, err := kagome.NewUserDic(userDict)
+		if err != nil {
+			return nil, err
+		}
+		k.SetUserDic(udic)
+	}
+
+	return &ipadicAnalyzer{k}, nil
+}
+
+type ipadicAnalyzer struct {
+	kagome kagome.Tokenizer
+}
+
+// Analyze picks the features of IPADIC:
+//
+//   0: part-of-speech
+//   1: sub-class 1
+//   2: sub-class 2
+//   3: sub-class 3
+//   4: inflection
+//   5: conjugation
+//   6: root-form
+//   7: reading
+//   8: pronunciation
+//
+func (a *ipadicAnalyzer) Analyze(word string, mode Mode) []Morpheme {
+	var kmode kagome.TokenizeMode
+	switch mode {
+	case Search:
+		kmode = kagome.Search
+	case Extended:
+		kmode = kagome.Extended
+	default:
+		kmode = kagome.Normal
+	}
+
+	var mors []Morpheme
+
+	for _, tok := range a.kagome.Analyze(word, kmode) {
+		mor := Morpheme{Surface: tok.Surface}
+		fs := tok.Features()
+
+		switch tok.Class {
+		case kagome.DUMMY:
+			continue
+
+		case kagome.KNOWN:
+			mor.Class = Known
+			mor.PartOfSpeech = feature(fs, 0)
+			mor.SubClass1 = feature(fs, 1)
+			mor.SubClass2 = feature(fs, 2)
+			mor.RootForm = feature(fs, 6)
+			mor.Reading = feature(fs, 7)
+			mor.Pronunciation = feature(fs, 8)
+			mor.Meta = mor.PartOfSpeech == "フィラー" || mor.PartOfSpeech == "記号"
+
+		case kagome.USER:
+			mor.Class = User
+			mor.PartOfSpeech = feature(fs, 0)
+			mor.Reading = strings.Replace(feature(fs, 2), "/", "", -1)
+			mor.Pronunciation = mor.Reading
+		}
+
+		mors = append(mors, mor)
+	}
+
+	return mors
+}
+
+// feature returns the i-th feature. It returns an empty string if there's no
+// such feature.
+func feature(fs []string, i int) string {
+	if i < 0 || i >= len(fs) {
+		return ""
+	}
+	return fs[i]
+}
@@ -1,42 +1,261 @@
 /*
 Package furigana implements the hangulize.Phonemizer interface for Japanese
 Kanji. Kanji has very broad characters so they need a dictionary to be
-converted to Kana. This phonemizer uses IPADIC in Kagome to analyze Kanji.
+converted to Kana. This phonemizer uses Kagome to analyze Kanji. IPADIC is
+the default dictionary. UniDic is also available in the unidic package:
+
+	h.UsePhonemizer(furigana.New(furigana.WithDict(unidic.Dict)))
+
+IPADIC doesn't know every proper noun. A user dictionary can fix the readings
+of them:
+
+	dict := furigana.NewUserDict()
+	dict.Add("八十二銀行", "ハチジューニギンコー", "固有名詞")
+
+	h := hangulize.NewHangulizer(spec)
+	h.UsePhonemizer(furigana.New(furigana.WithUserDict(dict)))
 */
 package furigana
 
 import (
-	kagome "github.com/ikawaha/kagome.ipadic/tokenizer"
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/hangulize/hangulize/phonemize"
 )
 
 // P is the Furigana phonemizer.
-var P furiganaPhonemizer
+var P Phonemizer
+
+// ReadingOption is the PhonemizeContext option key to choose which Kana of a
+// morpheme is used:
+//
+//   "pronunciation": 東京 -> トーキョー (default)
+//   "reading":       東京 -> トウキョウ
+//
+const ReadingOption = "furigana.reading"
+
+// Token is a morpheme with the Kana reading. It consists of a core analyzed
+// morpheme and the following auxiliary morphemes, such as "食べよう".
+type Token struct {
+	Surface string // The original spelling.
+	Reading string // The reading in Katakana.
+
+	// Category is how the typewriter treats the morpheme. It is one of
+	// "morpheme", "personName", "properNoun", "meta" or "unknown".
+	Category string
+
+	// Known is false if no dictionary knows the reading. The reading of an
+	// unknown morpheme is just the surface.
+	Known bool
+}
 
 // ----------------------------------------------------------------------------
 
-type furiganaPhonemizer struct {
-	kagome *kagome.Tokenizer
+// Phonemizer is the Furigana phonemizer. The zero value is ready to use with
+// the default settings. Use New to customize it.
+type Phonemizer struct {
+	dict     Dict
+	mode     Mode
+	userDict *UserDict
+
+	analyzer Analyzer
+	err      error
+	once     sync.Once
+}
+
+// Mode is a tokenization mode for the analysis.
+type Mode int
+
+// Tokenization modes. Search mode splits compound nouns into smaller ones. It
+// helps to detect person names. Extended mode is Search mode which also splits
+// unknown words into characters.
+const (
+	Normal Mode = iota
+	Search
+	Extended
+)
+
+// Option customizes a Phonemizer.
+type Option func(*Phonemizer)
+
+// WithDict chooses the system dictionary. IPADIC is the default.
+func WithDict(dict Dict) Option {
+	return func(p *Phonemizer) {
+		p.dict = dict
+	}
+}
+
+// WithMode chooses the tokenization mode. Normal is the default.
+func WithMode(mode Mode) Option {
+	return func(p *Phonemizer) {
+		p.mode = mode
+	}
 }
 
-func (furiganaPhonemizer) ID() string {
+// WithUserDict makes a Phonemizer prefer the readings in the user dictionary.
+// The entries added to the dictionary after the first phonemization are
+// ignored.
+func WithUserDict(dict *UserDict) Option {
+	return func(p *Phonemizer) {
+		p.userDict = dict
+	}
+}
+
+// New creates a Phonemizer. Unlike the global P, it can be used only for a
+// Hangulizer:
+//
+//   h.UsePhonemizer(furigana.New(furigana.WithUserDict(dict)))
+//
+func New(opts ...Option) *Phonemizer {
+	p := &Phonemizer{}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// ID returns "furigana".
+func (*Phonemizer) ID() string {
 	return "furigana"
 }
 
-// Kagome caches d Kagome tokenizer because it is expensive.
-func (p *furiganaPhonemizer) Kagome() *kagome.Tokenizer {
-	if p.kagome == nil {
-		k := kagome.New()
-		p.kagome = &k
+// Analyzer caches the Analyzer of the system dictionary because it is
+// expensive. It is safe to call concurrently.
+//
+// It fails if the user dictionary couldn't be loaded.
+func (p *Phonemizer) Analyzer() (Analyzer, error) {
+	p.once.Do(func() {
+		dict := p.dict
+		if dict == nil {
+			dict = IPADIC
+		}
+
+		path := ""
+		if p.userDict != nil {
+			path, p.err = p.userDict.writeFile()
+			if p.err != nil {
+				return
+			}
+			defer os.Remove(path)
+		}
+
+		p.analyzer, p.err = dict.NewAnalyzer(path)
+	})
+	return p.analyzer, p.err
+}
+
+// Phonemize converts Kanji in a word to Katakana.
+func (p *Phonemizer) Phonemize(word string) string {
+	word, _ = p.PhonemizeContext(context.Background(), word, nil)
+	return word
+}
+
+// PhonemizeContext implements hangulize.ContextPhonemizer. Kagome can't be
+// interrupted. So the context is checked before and after the analysis.
+//
+// It fails if the user dictionary couldn't be loaded.
+func (p *Phonemizer) PhonemizeContext(
+	ctx context.Context,
+	word string,
+	opts map[string]interface{},
+) (string, error) {
+	tw, err := p.typewrite(ctx, word, opts)
+	if err != nil {
+		return word, err
+	}
+	return tw.Typewrite(), nil
+}
+
+// Analyze returns the morphemes in a word with the readings. The readings of
+// them make the result of Phonemize.
+func (p *Phonemizer) Analyze(word string) []Token {
+	tokens, _ := p.AnalyzeContext(context.Background(), word, nil)
+	return tokens
+}
+
+// AnalyzeContext is Analyze with a context and options. The options are the
+// same with PhonemizeContext.
+func (p *Phonemizer) AnalyzeContext(
+	ctx context.Context,
+	word string,
+	opts map[string]interface{},
+) ([]Token, error) {
+	tw, err := p.typewrite(ctx, word, opts)
+	if err != nil {
+		return nil, err
+	}
+	return tw.Morphemes(), nil
+}
+
+// PhonemizeSegments implements hangulize.SegmentPhonemizer. The note of a
+// segment is the category of the morpheme.
+func (p *Phonemizer) PhonemizeSegments(
+	ctx context.Context,
+	word string,
+	opts map[string]interface{},
+) ([]phonemize.Segment, error) {
+	tokens, err := p.AnalyzeContext(ctx, word, opts)
+	if err != nil {
+		return nil, err
 	}
-	return p.kagome
+
+	segs := make([]phonemize.Segment, len(tokens))
+	for i, tok := range tokens {
+		note := tok.Category
+		if !tok.Known && tok.Category != "meta" {
+			note += ", unknown"
+		}
+		segs[i] = phonemize.Segment{
+			Surface: tok.Surface,
+			Reading: tok.Reading,
+			Note:    note,
+		}
+	}
+	return segs, nil
 }
 
-func (p *furiganaPhonemizer) Phonemize(word string) string {
+// typewrite analyzes a word and prepares a typewriter for it.
+func (p *Phonemizer) typewrite(
+	ctx context.Context,
+	word string,
+	opts map[string]interface{},
+) (*typewriter, error) {
+	useReading := false
+
+	if opt, ok := opts[ReadingOption]; ok {
+		switch opt {
+		case "pronunciation":
+		case "reading":
+			useReading = true
+		default:
+			return nil, fmt.Errorf("unknown %s: %v", ReadingOption, opt)
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	a, err := p.Analyzer()
+	if err != nil {
+		return nil, err
+	}
+
 	word = repeatKana(word)
 
-	tokens := p.Kagome().Tokenize(word)
-	tw := newTypewriter(tokens)
-	word = tw.Typewrite()
+	tw := newTypewriter(a.Analyze(word, p.mode))
+	tw.useReading = useReading
 
-	return word
+	if class, ok := opts[phonemize.WordClassOption].(string); ok {
+		tw.wordClass = class
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return tw, nil
 }
@@ -1,15 +1,43 @@
 package furigana
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/hangulize/hangulize"
+	"github.com/hangulize/hangulize/phonemize"
 )
 
 func TestPhonemizer(t *testing.T) {
 	assert.Implements(t, (*hangulize.Phonemizer)(nil), &P)
+	assert.Implements(t, (*hangulize.ContextPhonemizer)(nil), &P)
+}
+
+func TestReadingOption(t *testing.T) {
+	ctx := context.Background()
+
+	word, err := P.PhonemizeContext(ctx, "東京", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "トーキョー", word)
+
+	opts := map[string]interface{}{ReadingOption: "reading"}
+	word, err = P.PhonemizeContext(ctx, "東京", opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "トウキョウ", word)
+
+	opts = map[string]interface{}{ReadingOption: "romaji"}
+	_, err = P.PhonemizeContext(ctx, "東京", opts)
+	assert.Error(t, err)
+}
+
+func TestPhonemizeContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := P.PhonemizeContext(ctx, "東京", nil)
+	assert.Equal(t, context.Canceled, err)
 }
 
 func TestKana(t *testing.T) {
@@ -56,3 +84,141 @@ func TestAmbiguousLinkedShortVowels(t *testing.T) {
 func TestLongVowelAcrossMorphemes(t *testing.T) {
 	assert.Equal(t, "ハナサナカロー", P.Phonemize("話さなかろう"))
 }
+
+// -----------------------------------------------------------------------------
+// Modes
+
+var ipadicSearch = New(WithMode(Search))
+
+func surfaces(tokens []Token) []string {
+	var ss []string
+	for _, tok := range tokens {
+		ss = append(ss, tok.Surface)
+	}
+	return ss
+}
+
+func TestModesPersonNames(t *testing.T) {
+	for _, p := range []*Phonemizer{&P, ipadicSearch} {
+		assert.Equal(t, []Token{
+			{"新海", "シンカイ", "personName", true},
+			{"", " ", "meta", true},
+			{"誠", "マコト", "personName", true},
+		}, p.Analyze("新海誠"))
+		assert.Equal(t, "シンカイ マコト", p.Phonemize("新海 誠"))
+	}
+}
+
+func TestModesProperNouns(t *testing.T) {
+	for _, p := range []*Phonemizer{&P, ipadicSearch} {
+		assert.Equal(t, []Token{
+			{"遠い", "トーイ", "morpheme", true},
+			{"北海道", "ホッカイドー", "properNoun", true},
+		}, p.Analyze("遠い北海道"))
+	}
+}
+
+func TestSearchModeSplitsCompounds(t *testing.T) {
+	assert.Equal(t, []string{"関西国際空港"}, surfaces(P.Analyze("関西国際空港")))
+	assert.Equal(t,
+		[]string{"関西", "国際", "空港"},
+		surfaces(ipadicSearch.Analyze("関西国際空港")),
+	)
+
+	// The text is the same. Only the morphemes are split.
+	assert.Equal(t,
+		P.Phonemize("関西国際空港"),
+		ipadicSearch.Phonemize("関西国際空港"),
+	)
+}
+
+func TestWithDict(t *testing.T) {
+	d := &fakeDict{}
+	p := New(WithDict(d))
+
+	assert.Equal(t, "ネコハ", p.Phonemize("猫は"))
+	assert.Equal(t, "ネコハ", p.Phonemize("猫は"))
+	assert.Equal(t, 1, d.analyzers)
+}
+
+// fakeDict knows only "猫は" in a UniDic-like way.
+type fakeDict struct {
+	analyzers int
+}
+
+func (d *fakeDict) NewAnalyzer(userDict string) (Analyzer, error) {
+	d.analyzers++
+	return d, nil
+}
+
+func (d *fakeDict) Analyze(word string, mode Mode) []Morpheme {
+	return []Morpheme{
+		{Surface: "猫", Class: Known, PartOfSpeech: "名詞", Pronunciation: "ネコ"},
+		{Surface: "は", Class: Known, PartOfSpeech: "助詞", RootForm: "は", Pronunciation: "ワ"},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Analysis
+
+func TestAnalyze(t *testing.T) {
+	assert.Equal(t, []Token{
+		{"新海", "シンカイ", "personName", true},
+		{"", " ", "meta", true},
+		{"誠", "マコト", "personName", true},
+	}, P.Analyze("新海誠"))
+
+	assert.Equal(t, []Token{
+		{"食べよう", "タベヨー", "morpheme", true},
+	}, P.Analyze("食べよう"))
+}
+
+func TestAnalyzeUnknown(t *testing.T) {
+	tokens := P.Analyze("ホウオウ")
+
+	assert.Len(t, tokens, 1)
+	assert.Equal(t, "ホーオー", tokens[0].Reading)
+	assert.Equal(t, "unknown", tokens[0].Category)
+	assert.False(t, tokens[0].Known)
+}
+
+func TestAnalyzeUserDict(t *testing.T) {
+	d := NewUserDict()
+	d.Add("鬼舞辻", "キブツジ", "人名")
+	d.Add("無惨", "ムザン", "人名")
+
+	p := New(WithUserDict(d))
+
+	assert.Equal(t, []Token{
+		{"鬼舞辻", "キブツジ", "personName", true},
+		{"", " ", "meta", true},
+		{"無惨", "ムザン", "personName", true},
+	}, p.Analyze("鬼舞辻無惨"))
+}
+
+func TestPhonemizeSegments(t *testing.T) {
+	assert.Implements(t, (*hangulize.SegmentPhonemizer)(nil), &P)
+
+	d := NewUserDict()
+	d.Add("鬼舞辻", "キブツジ", "人名")
+
+	p := New(WithUserDict(d))
+	segs, err := p.PhonemizeSegments(context.Background(), "鬼舞辻", nil)
+
+	assert.NoError(t, err)
+	assert.Len(t, segs, 1)
+	assert.Equal(t, "personName", segs[0].Note)
+}
+
+func TestHintCategory(t *testing.T) {
+	assert.Equal(t, properNoun, hintCategory(properNoun, ""))
+	assert.Equal(t, personName, hintCategory(personName, ""))
+
+	assert.Equal(t, personName, hintCategory(properNoun, phonemize.PersonName))
+	assert.Equal(t, morpheme, hintCategory(morpheme, phonemize.PersonName))
+
+	assert.Equal(t, properNoun, hintCategory(personName, phonemize.PlaceName))
+	assert.Equal(t, properNoun, hintCategory(personName, phonemize.GivenName))
+	assert.Equal(t, properNoun, hintCategory(personName, phonemize.Surname))
+	assert.Equal(t, properNoun, hintCategory(personName, phonemize.CommonNoun))
+}
@@ -5,7 +5,7 @@ import (
 	"strings"
 	"unicode/utf8"
 
-	kagome "github.com/ikawaha/kagome.ipadic/tokenizer"
+	"github.com/hangulize/hangulize/phonemize"
 )
 
 type category int
@@ -21,20 +21,43 @@ const (
 	unknown
 )
 
-// typewriter writes a whole pronunciation from the Kagome tokens.
+var categoryNames = [...]string{
+	illegal:    "illegal",
+	meta:       "meta",
+	morpheme:   "morpheme",
+	auxiliary:  "auxiliary",
+	properNoun: "properNoun",
+	personName: "personName",
+	unknown:    "unknown",
+}
+
+func (c category) String() string {
+	return categoryNames[c]
+}
+
+// typewriter writes a whole pronunciation from the analyzed morphemes.
 type typewriter struct {
-	tokens  []kagome.Token
+	tokens  []Morpheme
 	result  string
 	cur     int
 	lastCat category
+
+	// useReading makes it write the reading instead of the pronunciation.
+	useReading bool
+
+	// wordClass is the hint by phonemize.WordClassOption.
+	wordClass string
+
+	// The written morphemes.
+	morphemes []Token
 }
 
-// newTypewriter initializes a typewriter for the Kagome tokens.
-func newTypewriter(tokens []kagome.Token) *typewriter {
-	return &typewriter{tokens, "", -1, illegal}
+// newTypewriter initializes a typewriter for the analyzed morphemes.
+func newTypewriter(tokens []Morpheme) *typewriter {
+	return &typewriter{tokens, "", -1, illegal, false, "", nil}
 }
 
-// Typewrite returns a whole pronunciation from the Kagome tokens.
+// Typewrite returns a whole pronunciation from the analyzed morphemes.
 func (t *typewriter) Typewrite() string {
 	// Re-use the cached result if already processed.
 	if t.cur != -1 {
@@ -44,22 +67,37 @@ func (t *typewriter) Typewrite() string {
 	var buf bytes.Buffer
 
 	for {
-		sep, str := t.scanMorpheme()
-		if str == "" {
+		sep, mor := t.scanMorpheme()
+		if mor == nil {
 			break
 		}
 
+		// A separator is written as a morpheme without surface.
+		if sep != "" {
+			t.morphemes = append(t.morphemes, Token{"", sep, meta.String(), true})
+		}
+		t.morphemes = append(t.morphemes, *mor)
+
 		buf.WriteString(sep)
-		buf.WriteString(str)
+		buf.WriteString(mor.Reading)
 	}
 
 	t.result = buf.String()
 	return t.result
 }
 
-// scanMorpheme consumes the Kagome tokens one by one.
-func (t *typewriter) scanMorpheme() (sep string, str string) {
+// Morphemes returns the written morphemes. A morpheme consists of a core
+// token and the following auxiliary tokens. The readings of them
+// make the result of Typewrite.
+func (t *typewriter) Morphemes() []Token {
+	t.Typewrite()
+	return t.morphemes
+}
+
+// scanMorpheme consumes the analyzed morphemes one by one.
+func (t *typewriter) scanMorpheme() (sep string, mor *Token) {
 	var buf bytes.Buffer
+	var surface bytes.Buffer
 
 	// -------------------------------------------------------------------------
 	// 1. The Core Morpheme
@@ -69,7 +107,10 @@ func (t *typewriter) scanMorpheme() (sep string, str string) {
 		return
 	}
 
-	str, cat := interpretToken(tok)
+	str, cat := interpretToken(tok, t.useReading)
+	cat = hintCategory(cat, t.wordClass)
+	known := tok.Class != Unknown
+	surface.WriteString(tok.Surface)
 
 	// Merge long vowels in an unknown word. Because Kagome didn't detect the
 	// pronunciation of this word.
@@ -104,9 +145,10 @@ func (t *typewriter) scanMorpheme() (sep string, str string) {
 			break
 		}
 
-		str, cat := interpretToken(tok)
+		str, cat := interpretToken(tok, t.useReading)
 		if cat == auxiliary {
 			buf.WriteString(str)
+			surface.WriteString(tok.Surface)
 		} else {
 			t.unread()
 			break
@@ -117,68 +159,69 @@ func (t *typewriter) scanMorpheme() (sep string, str string) {
 	str = buf.String()
 	str = mergeLongVowels(str, coreLen)
 
-	return sep, str
+	return sep, &Token{surface.String(), str, cat.String(), known}
 }
 
-func (t *typewriter) read() *kagome.Token {
-	var tok *kagome.Token
-
-	// Scan the next non-dummy token.
-	for tok == nil || tok.Class == kagome.DUMMY {
-		t.cur++
-
-		if t.cur >= len(t.tokens) {
-			return nil
+// hintCategory adjusts the category of a core morpheme by the word class
+// hint. A full name splits into a surname and a given name by the person name
+// morphemes. But the others never split:
+//
+//   person:            上条当麻 -> カミジョー トーマ
+//   place, given-name: 上条当麻 -> カミジョートーマ
+//
+func hintCategory(cat category, wordClass string) category {
+	switch wordClass {
+	case phonemize.PersonName:
+		if cat == properNoun {
+			return personName
 		}
+	case phonemize.GivenName, phonemize.Surname, phonemize.PlaceName, phonemize.CommonNoun:
+		if cat == personName {
+			return properNoun
+		}
+	}
+	return cat
+}
+
+func (t *typewriter) read() *Morpheme {
+	t.cur++
 
-		tok = &t.tokens[t.cur]
+	if t.cur >= len(t.tokens) {
+		return nil
 	}
 
-	return tok
+	return &t.tokens[t.cur]
 }
 
 func (t *typewriter) unread() {
 	t.cur--
 }
 
-// interpretToken picks a pronunciation and category from a Kagome token.
-func interpretToken(tok *kagome.Token) (string, category) {
+// interpretToken picks a pronunciation (or reading) and category from an
+// analyzed morpheme.
+func interpretToken(tok *Morpheme, useReading bool) (string, category) {
 	str := tok.Surface
 	cat := unknown
 
-	if tok.Class == kagome.KNOWN {
-		// 0: part-of-speech
-		// 1: sub-class 1
-		// 2: sub-class 2
-		// 3: sub-class 3
-		// 4: inflection
-		// 5: conjugation
-		// 6: root-form
-		// 7: reading
-		// 8: pronunciation
-		fs := tok.Features()
-		var (
-			partOfSpeech  = fs[0]
-			subClass1     = fs[1]
-			subClass2     = fs[2]
-			rootForm      = fs[6]
-			pronunciation = fs[8]
-		)
-
-		str = pronunciation
+	if tok.Class == Known {
+		if useReading && tok.Reading != "" {
+			str = tok.Reading
+		} else if tok.Pronunciation != "" {
+			str = tok.Pronunciation
+		}
 		cat = morpheme
 
-		switch partOfSpeech {
+		switch {
 
-		case "フィラー", "記号":
+		case tok.Meta:
 			cat = meta
 
-		case "助動詞":
+		case tok.PartOfSpeech == "助動詞":
 			cat = auxiliary
 
-		case "助詞":
+		case tok.PartOfSpeech == "助詞":
 			// Keep the root form of particles.
-			switch rootForm {
+			switch tok.RootForm {
 			case "は":
 				str = "ハ"
 			case "へ":
@@ -186,13 +229,20 @@ func interpretToken(tok *kagome.Token) (string, category) {
 			}
 
 		default:
-			if subClass2 == "人名" {
+			if tok.SubClass2 == "人名" {
 				cat = personName
-			} else if subClass1 == "固有名詞" {
+			} else if tok.SubClass1 == "固有名詞" {
 				cat = properNoun
 			}
 
 		}
+	} else if tok.Class == User {
+		str = tok.Reading
+		cat = properNoun
+
+		if tok.PartOfSpeech == "人名" {
+			cat = personName
+		}
 	} else {
 		isSpace := strings.TrimSpace(str) == ""
 		if isSpace {
@@ -3,20 +3,34 @@ package furigana
 import (
 	"testing"
 
-	kagome "github.com/ikawaha/kagome.ipadic/tokenizer"
 	"github.com/stretchr/testify/assert"
 )
 
+func analyze(word string) []Morpheme {
+	a, _ := IPADIC.NewAnalyzer("")
+	return a.Analyze(word, Normal)
+}
+
 func TestTypewriterAuxiliary(t *testing.T) {
-	toks := kagome.New().Tokenize("食べよう")
+	toks := analyze("食べよう")
 	tw := newTypewriter(toks)
 
 	assert.Equal(t, "タベヨー", tw.Typewrite())
 }
 
 func TestTypewriterUnknown(t *testing.T) {
-	toks := kagome.New().Tokenize("ホウオウ")
+	toks := analyze("ホウオウ")
 	tw := newTypewriter(toks)
 
 	assert.Equal(t, "ホーオー", tw.Typewrite())
 }
+
+func TestTypewriterPersonNames(t *testing.T) {
+	toks := []Morpheme{
+		{Surface: "新海", Class: Known, PartOfSpeech: "名詞", SubClass1: "固有名詞", SubClass2: "人名", Pronunciation: "シンカイ"},
+		{Surface: "誠", Class: Known, PartOfSpeech: "名詞", SubClass1: "固有名詞", SubClass2: "人名", Pronunciation: "マコト"},
+	}
+	tw := newTypewriter(toks)
+
+	assert.Equal(t, "シンカイ マコト", tw.Typewrite())
+}
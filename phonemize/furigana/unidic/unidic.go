@@ -0,0 +1,112 @@
+/*
+Package unidic provides UniDic for the furigana phonemizer. UniDic reads
+person names better than IPADIC. But it is too huge to be in the furigana
+package by default:
+
+	h.UsePhonemizer(furigana.New(furigana.WithDict(unidic.Dict)))
+*/
+package unidic
+
+import (
+	"strings"
+
+	kagome "github.com/ikawaha/kagome/tokenizer"
+
+	"github.com/hangulize/hangulize/phonemize/furigana"
+)
+
+// Dict is UniDic.
+var Dict furigana.Dict = dict{}
+
+type dict struct{}
+
+// NewAnalyzer creates an Analyzer with Kagome.
+func (dict) NewAnalyzer(userDict string) (furigana.Analyzer, error) {
+	k := kagome.NewWithDic(kagome.SysDicUni())
+
+	if userDict != "" {
+		udic, err := kagome.NewUserDic(userDict)
+		if err != nil {
+			return nil, err
+		}
+		k.SetUserDic(udic)
+	}
+
+	return &analyzer{k}, nil
+}
+
+type analyzer struct {
+	kagome kagome.Tokenizer
+}
+
+// Analyze picks the features of UniDic. UniDic doesn't provide the reading of
+// a surface. So the pronunciation is used for the reading too:
+//
+//   0: pos1
+//   1: pos2
+//   2: pos3
+//   3: pos4
+//   4: cType
+//   5: cForm
+//   6: lForm
+//   7: lemma
+//   8: orth
+//   9: pron
+//   ...
+//
+func (a *analyzer) Analyze(word string, mode furigana.Mode) []furigana.Morpheme {
+	var kmode kagome.TokenizeMode
+	switch mode {
+	case furigana.Search:
+		kmode = kagome.Search
+	case furigana.Extended:
+		kmode = kagome.Extended
+	default:
+		kmode = kagome.Normal
+	}
+
+	var mors []furigana.Morpheme
+
+	for _, tok := range a.kagome.Analyze(word, kmode) {
+		mor := furigana.Morpheme{Surface: tok.Surface}
+		fs := tok.Features()
+
+		switch tok.Class {
+		case kagome.DUMMY:
+			continue
+
+		case kagome.KNOWN:
+			mor.Class = furigana.Known
+			mor.PartOfSpeech = feature(fs, 0)
+			mor.SubClass1 = feature(fs, 1)
+			mor.SubClass2 = feature(fs, 2)
+			mor.RootForm = feature(fs, 7)
+			mor.Reading = feature(fs, 9)
+			mor.Pronunciation = mor.Reading
+
+			switch mor.PartOfSpeech {
+			case "補助記号", "空白", "記号":
+				mor.Meta = true
+			}
+
+		case kagome.USER:
+			mor.Class = furigana.User
+			mor.PartOfSpeech = feature(fs, 0)
+			mor.Reading = strings.Replace(feature(fs, 2), "/", "", -1)
+			mor.Pronunciation = mor.Reading
+		}
+
+		mors = append(mors, mor)
+	}
+
+	return mors
+}
+
+// feature returns the i-th feature. It returns an empty string if there's no
+// such feature.
+func feature(fs []string, i int) string {
+	if i < 0 || i >= len(fs) {
+		return ""
+	}
+	return fs[i]
+}
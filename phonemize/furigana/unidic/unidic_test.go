@@ -0,0 +1,50 @@
+package unidic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hangulize/hangulize/phonemize/furigana"
+)
+
+var (
+	unidicNormal = furigana.New(furigana.WithDict(Dict))
+	unidicSearch = furigana.New(furigana.WithDict(Dict), furigana.WithMode(furigana.Search))
+)
+
+func TestUniDicPersonNames(t *testing.T) {
+	for _, p := range []*furigana.Phonemizer{unidicNormal, unidicSearch} {
+		assert.Equal(t, []furigana.Token{
+			{Surface: "新海", Reading: "シンカイ", Category: "personName", Known: true},
+			{Surface: "", Reading: " ", Category: "meta", Known: true},
+			{Surface: "誠", Reading: "マコト", Category: "personName", Known: true},
+		}, p.Analyze("新海誠"))
+		assert.Equal(t, "シンカイ マコト", p.Phonemize("新海 誠"))
+	}
+}
+
+func TestUniDicProperNouns(t *testing.T) {
+	for _, p := range []*furigana.Phonemizer{unidicNormal, unidicSearch} {
+		assert.Equal(t, []furigana.Token{
+			{Surface: "遠い", Reading: "トーイ", Category: "morpheme", Known: true},
+			{Surface: "北海道", Reading: "ホッカイドー", Category: "properNoun", Known: true},
+		}, p.Analyze("遠い北海道"))
+	}
+}
+
+func TestUniDicParticles(t *testing.T) {
+	// The root form of particles is kept in UniDic too.
+	assert.Equal(t, "ネコハ", unidicNormal.Phonemize("猫は"))
+}
+
+func TestUniDicUserDict(t *testing.T) {
+	d := furigana.NewUserDict()
+	d.Add("鬼舞辻", "キブツジ", "人名")
+
+	p := furigana.New(furigana.WithDict(Dict), furigana.WithUserDict(d))
+
+	assert.Equal(t, []furigana.Token{
+		{Surface: "鬼舞辻", Reading: "キブツジ", Category: "personName", Known: true},
+	}, p.Analyze("鬼舞辻"))
+}
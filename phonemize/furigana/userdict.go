@@ -0,0 +1,151 @@
+package furigana
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+)
+
+// defaultPos is the part-of-speech of a user dictionary entry without the
+// explicit one. Most entries are expected to be proper nouns.
+const defaultPos = "固有名詞"
+
+// UserDict is a user dictionary which maps surfaces to readings in Katakana.
+// Kagome prefers the entries in a user dictionary to the system dictionary.
+//
+// The part-of-speech of an entry decides how the typewriter treats it. "人名"
+// is a person name, and the others are proper nouns.
+type UserDict struct {
+	mu      sync.Mutex
+	entries []userDictEntry
+}
+
+type userDictEntry struct {
+	surface string
+	reading string
+	pos     string
+}
+
+// NewUserDict creates an empty user dictionary.
+func NewUserDict() *UserDict {
+	return &UserDict{}
+}
+
+// Add adds an entry to the user dictionary. The part-of-speech is optional.
+func (d *UserDict) Add(surface, reading string, pos ...string) error {
+	entry := userDictEntry{surface, reading, defaultPos}
+	if len(pos) != 0 {
+		entry.pos = pos[0]
+	}
+
+	for _, field := range []string{entry.surface, entry.reading, entry.pos} {
+		if field == "" || strings.ContainsAny(field, ", \t\r\n") {
+			return fmt.Errorf("invalid user dictionary entry: %#v", field)
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.entries = append(d.entries, entry)
+	return nil
+}
+
+// Len returns the number of entries.
+func (d *UserDict) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return len(d.entries)
+}
+
+// ReadUserDict reads a user dictionary. Each line has a surface, a reading
+// and an optional part-of-speech separated by commas. Empty lines and lines
+// starting with "#" are ignored:
+//
+//   # surface,reading[,part-of-speech]
+//   八十二銀行,ハチジューニギンコー
+//   新海,シンカイ,人名
+//
+func ReadUserDict(r io.Reader) (*UserDict, error) {
+	d := NewUserDict()
+	scanner := bufio.NewScanner(r)
+
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, ",")
+		if len(fields) < 2 || len(fields) > 3 {
+			return nil, fmt.Errorf("user dictionary line %d: %#v", lineNo, line)
+		}
+
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+
+		err := d.Add(fields[0], fields[1], fields[2:]...)
+		if err != nil {
+			return nil, fmt.Errorf("user dictionary line %d: %s", lineNo, err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// LoadUserDict reads a user dictionary file. See ReadUserDict for the format.
+func LoadUserDict(path string) (*UserDict, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadUserDict(f)
+}
+
+// writeFile writes the user dictionary to a temporary file. Kagome loads a
+// user dictionary only from a file in its own format:
+//
+//   surface,segmented surface,segmented reading,part-of-speech
+//
+// The caller should remove the file after loading it.
+func (d *UserDict) writeFile() (string, error) {
+	f, err := ioutil.TempFile("", "furigana-userdict")
+	if err != nil {
+		return "", err
+	}
+
+	w := bufio.NewWriter(f)
+
+	d.mu.Lock()
+	for _, e := range d.entries {
+		fmt.Fprintf(w, "%s,%s,%s,%s\n", e.surface, e.surface, e.reading, e.pos)
+	}
+	d.mu.Unlock()
+
+	if err := w.Flush(); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+
+	return f.Name(), nil
+}
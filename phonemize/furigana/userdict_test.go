@@ -0,0 +1,72 @@
+package furigana
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadUserDict(t *testing.T) {
+	d, err := ReadUserDict(strings.NewReader(`
+	# surface,reading[,part-of-speech]
+	八十二銀行,ハチジューニギンコー
+
+	新海, シンカイ, 人名
+	`))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, d.Len())
+	assert.Equal(t, userDictEntry{"八十二銀行", "ハチジューニギンコー", "固有名詞"}, d.entries[0])
+	assert.Equal(t, userDictEntry{"新海", "シンカイ", "人名"}, d.entries[1])
+}
+
+func TestReadUserDictInvalid(t *testing.T) {
+	_, err := ReadUserDict(strings.NewReader("八十二銀行"))
+	assert.Error(t, err)
+
+	_, err = ReadUserDict(strings.NewReader("八十二銀行,ハチジューニ ギンコー"))
+	assert.Error(t, err)
+
+	_, err = ReadUserDict(strings.NewReader("a,b,c,d"))
+	assert.Error(t, err)
+}
+
+func TestLoadUserDict(t *testing.T) {
+	f, err := ioutil.TempFile("", "userdict")
+	assert.NoError(t, err)
+	defer os.Remove(f.Name())
+
+	f.WriteString("八十二銀行,ハチジューニギンコー\n")
+	f.Close()
+
+	d, err := LoadUserDict(f.Name())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, d.Len())
+
+	_, err = LoadUserDict(f.Name() + ".not-found")
+	assert.Error(t, err)
+}
+
+func TestUserDict(t *testing.T) {
+	d := NewUserDict()
+	assert.NoError(t, d.Add("東京", "ヒガシキョウ"))
+	assert.Error(t, d.Add("", "カラ"))
+
+	p := New(WithUserDict(d))
+	assert.Equal(t, "ヒガシキョウ", p.Phonemize("東京"))
+
+	// The global phonemizer isn't affected.
+	assert.Equal(t, "トーキョー", P.Phonemize("東京"))
+}
+
+func TestUserDictPersonName(t *testing.T) {
+	d := NewUserDict()
+	d.Add("鬼舞辻", "キブツジ", "人名")
+	d.Add("無惨", "ムザン", "人名")
+
+	p := New(WithUserDict(d))
+	assert.Equal(t, "キブツジ ムザン", p.Phonemize("鬼舞辻無惨"))
+}
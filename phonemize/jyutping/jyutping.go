@@ -0,0 +1,285 @@
+/*
+Package jyutping implements the hangulize.Phonemizer interface for Cantonese.
+It converts Hanzu to Jyutping, the romanization by the Linguistic Society of
+Hong Kong. The tones are dropped because Hangul can't express them. Each
+Hanzu is separated by ZWSP:
+
+	張國榮 -> zoeng gwok wing
+
+The bundled dictionary covers the Hanzu frequent in Hong Kong names. A user
+dictionary can add or override readings:
+
+	dict := jyutping.NewDict()
+	dict.Add('鄺', "kwong3")
+
+	h := hangulize.NewHangulizer(spec)
+	h.UsePhonemizer(jyutping.New(jyutping.WithDict(dict)))
+*/
+package jyutping
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/hangulize/hangulize/phonemize"
+)
+
+// P is the Jyutping phonemizer.
+var P Phonemizer
+
+// ----------------------------------------------------------------------------
+
+// Dict maps Hanzu to the readings in Jyutping with tone numbers.
+type Dict struct {
+	mu    sync.RWMutex
+	chars map[rune][]string
+}
+
+// NewDict creates an empty dictionary.
+func NewDict() *Dict {
+	return &Dict{chars: make(map[rune][]string)}
+}
+
+// Add adds the readings of a Hanzu. The most common reading should come
+// first. The readings replace the previous ones.
+func (d *Dict) Add(ch rune, readings ...string) error {
+	if len(readings) == 0 {
+		return fmt.Errorf("no reading of %#v", string(ch))
+	}
+
+	for _, reading := range readings {
+		if !isJyutping(reading) {
+			return fmt.Errorf("invalid reading of %#v: %#v", string(ch), reading)
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.chars[ch] = readings
+	return nil
+}
+
+// Len returns the number of Hanzu.
+func (d *Dict) Len() int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return len(d.chars)
+}
+
+// Lookup returns the readings of a Hanzu with tone numbers. It is safe with a
+// nil dictionary.
+func (d *Dict) Lookup(ch rune) ([]string, bool) {
+	if d == nil {
+		return nil, false
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	readings, ok := d.chars[ch]
+	return readings, ok
+}
+
+// ReadDict reads a dictionary. Each line has Hanzu which share the readings
+// and the readings separated by spaces. Empty lines and lines starting with
+// "#" are ignored:
+//
+//   # hanzu reading...
+//   張张 zoeng1
+//   長长 coeng4 zoeng2
+//
+func ReadDict(r io.Reader) (*Dict, error) {
+	d := NewDict()
+	scanner := bufio.NewScanner(r)
+
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("jyutping dictionary line %d: %#v", lineNo, line)
+		}
+
+		for _, ch := range fields[0] {
+			err := d.Add(ch, fields[1:]...)
+			if err != nil {
+				return nil, fmt.Errorf("jyutping dictionary line %d: %s", lineNo, err)
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// LoadDict reads a dictionary file. See ReadDict for the format.
+func LoadDict(path string) (*Dict, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadDict(f)
+}
+
+// isJyutping checks whether a reading is lower case letters followed by a
+// tone number from 1 to 6.
+func isJyutping(reading string) bool {
+	n := len(reading)
+	if n < 2 {
+		return false
+	}
+
+	if tone := reading[n-1]; tone < '1' || tone > '6' {
+		return false
+	}
+
+	for i := 0; i < n-1; i++ {
+		if reading[i] < 'a' || reading[i] > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// bundled is the bundled dictionary.
+var bundled = mustReadDict(table)
+
+func mustReadDict(table string) *Dict {
+	d, err := ReadDict(strings.NewReader(table))
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// ----------------------------------------------------------------------------
+
+// Phonemizer is the Jyutping phonemizer. The zero value is ready to use with
+// the bundled dictionary. Use New to customize it.
+type Phonemizer struct {
+	dict *Dict
+}
+
+// Option customizes a Phonemizer.
+type Option func(*Phonemizer)
+
+// WithDict makes a Phonemizer prefer the readings in the user dictionary to
+// the bundled ones.
+func WithDict(dict *Dict) Option {
+	return func(p *Phonemizer) {
+		p.dict = dict
+	}
+}
+
+// New creates a Phonemizer. Unlike the global P, it can be used only for a
+// Hangulizer:
+//
+//   h.UsePhonemizer(jyutping.New(jyutping.WithDict(dict)))
+//
+func New(opts ...Option) *Phonemizer {
+	p := &Phonemizer{}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// ID returns "jyutping".
+func (*Phonemizer) ID() string {
+	return "jyutping"
+}
+
+// Lookup returns the readings of a Hanzu with tone numbers. The user
+// dictionary is preferred.
+func (p *Phonemizer) Lookup(ch rune) ([]string, bool) {
+	if readings, ok := p.dict.Lookup(ch); ok {
+		return readings, true
+	}
+	return bundled.Lookup(ch)
+}
+
+// Phonemize converts Hanzu in a word to Jyutping without tones. Each Hanzu is
+// separated by ZWSP. Unknown Hanzu are kept.
+func (p *Phonemizer) Phonemize(word string) string {
+	var buf bytes.Buffer
+	for _, seg := range p.segments(word) {
+		buf.WriteString(seg.Reading)
+	}
+	return buf.String()
+}
+
+// PhonemizeSegments implements hangulize.SegmentPhonemizer. Each Hanzu is a
+// segment. The note of a segment is the reading with the tone number.
+func (p *Phonemizer) PhonemizeSegments(
+	ctx context.Context,
+	word string,
+	opts map[string]interface{},
+) ([]phonemize.Segment, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return p.segments(word), nil
+}
+
+// segments splits a word into Hanzu with the Jyutping and the other chunks.
+// The segments are separated by ZWSP.
+func (p *Phonemizer) segments(word string) []phonemize.Segment {
+	var segs []phonemize.Segment
+	var buf bytes.Buffer
+
+	appendSeg := func(seg phonemize.Segment) {
+		if len(segs) != 0 {
+			segs = append(segs, phonemize.Segment{Reading: "\u200b"})
+		}
+		segs = append(segs, seg)
+	}
+
+	flush := func() {
+		if buf.Len() != 0 {
+			chunk := buf.String()
+			appendSeg(phonemize.Segment{Surface: chunk, Reading: chunk, Note: "kept"})
+			buf.Reset()
+		}
+	}
+
+	for _, ch := range word {
+		readings, ok := p.Lookup(ch)
+
+		if !ok {
+			buf.WriteRune(ch)
+			continue
+		}
+
+		flush()
+
+		// Drop the tone number.
+		reading := readings[0]
+		appendSeg(phonemize.Segment{
+			Surface: string(ch),
+			Reading: reading[:len(reading)-1],
+			Note:    reading,
+		})
+	}
+	flush()
+
+	return segs
+}
@@ -0,0 +1,74 @@
+package jyutping
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hangulize/hangulize"
+	"github.com/hangulize/hangulize/phonemize"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhonemizer(t *testing.T) {
+	assert.Implements(t, (*hangulize.Phonemizer)(nil), &P)
+	assert.Implements(t, (*hangulize.SegmentPhonemizer)(nil), &P)
+}
+
+func TestJyutping(t *testing.T) {
+	assert.Equal(t, "zoeng\u200bgwok\u200bwing", P.Phonemize("張國榮"))
+	assert.Equal(t, "wong\u200bgok", P.Phonemize("旺角"))
+
+	// Simplified forms
+	assert.Equal(t, "zoeng\u200bgwok\u200bwing", P.Phonemize("张国荣"))
+}
+
+func TestJyutpingKept(t *testing.T) {
+	assert.Equal(t, "ab\u200bwong\u200b!", P.Phonemize("ab旺!"))
+}
+
+func TestPhonemizeSegments(t *testing.T) {
+	segs, err := P.PhonemizeSegments(context.Background(), "旺角!", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []phonemize.Segment{
+		{Surface: "旺", Reading: "wong", Note: "wong6"},
+		{Reading: "\u200b"},
+		{Surface: "角", Reading: "gok", Note: "gok3"},
+		{Reading: "\u200b"},
+		{Surface: "!", Reading: "!", Note: "kept"},
+	}, segs)
+}
+
+func TestUserDict(t *testing.T) {
+	dict := NewDict()
+	assert.NoError(t, dict.Add('角', "lok6"))
+	assert.NoError(t, dict.Add('鋼', "gong3"))
+
+	p := New(WithDict(dict))
+	assert.Equal(t, "wong\u200blok", p.Phonemize("旺角"))
+	assert.Equal(t, "gong\u200bgong", p.Phonemize("鋼港"))
+}
+
+func TestReadDict(t *testing.T) {
+	dict, err := ReadDict(strings.NewReader(`
+	# hanzu reading...
+	長长 coeng4 zoeng2
+	`))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, dict.Len())
+
+	readings, ok := dict.Lookup('长')
+	assert.True(t, ok)
+	assert.Equal(t, []string{"coeng4", "zoeng2"}, readings)
+}
+
+func TestReadDictInvalid(t *testing.T) {
+	_, err := ReadDict(strings.NewReader("長"))
+	assert.Error(t, err)
+
+	_, err = ReadDict(strings.NewReader("長 coeng"))
+	assert.Error(t, err)
+
+	_, err = ReadDict(strings.NewReader("長 Coeng4"))
+	assert.Error(t, err)
+}
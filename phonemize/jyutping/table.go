@@ -0,0 +1,318 @@
+package jyutping
+
+// table is the bundled Jyutping dictionary. Each line has Hanzu which share
+// the readings, such as the traditional and simplified forms, and the
+// readings with tone numbers. The most common reading comes first.
+//
+// It covers the Hanzu frequent in Hong Kong person names and place names.
+const table = `
+陳陈 can4
+李 lei5
+張张 zoeng1
+黃黄 wong4
+何 ho4
+林 lam4
+梁 loeng4
+劉刘 lau4
+吳吴 ng4
+王 wong4
+周 zau1
+鄭郑 zeng6
+楊杨 joeng4
+胡 wu4
+馬马 maa5
+朱 zyu1
+郭 gwok3
+高 gou1
+羅罗 lo4
+葉叶 jip6
+鄧邓 dang6
+謝谢 ze6
+曾 zang1
+蔡 coi3
+潘 pun1
+余 jyu4
+麥麦 mak6
+許许 heoi2
+關关 gwaan1
+鄺邝 kwong3
+彭 paang4
+袁 jyun4
+盧卢 lou4
+蘇苏 sou1
+譚谭 taam4
+莫 mok6
+成 sing4
+古 gu2
+杜 dou6
+姚 jiu4
+容 jung4
+甄 jan1
+鍾钟 zung1
+方 fong1
+孫孙 syun1
+趙赵 ziu6
+徐 ceoi4
+尹 wan5
+唐 tong4
+田 tin4
+石 sek6
+江 gong1
+范 faan6
+韋韦 wai4
+區区 keoi1 au1
+車车 ce1 geoi1
+洪 hung4
+萬万 maan6
+龍龙 lung4
+梅 mui4
+鄒邹 zau1
+歐欧 au1
+國国 gwok3
+榮荣 wing4
+華华 waa4
+德 dak1
+偉伟 wai5
+明 ming4
+文 man4
+強强 koeng4 koeng5
+志 zi3
+家 gaa1
+輝辉 fai1
+發发 faat3
+潤润 jeon6
+學学 hok6
+友 jau5
+富 fu3
+城 sing4
+小 siu2
+星 sing1
+馳驰 ci4
+嘉 gaa1
+玲 ling4
+美 mei5
+麗丽 lai6
+芳 fong1
+敏 man5
+慧 wai6
+婷 ting4
+儀仪 ji4
+詩诗 si1
+欣 jan1
+子 zi2
+天 tin1
+少 siu2 siu3
+卿 hing1
+曼 maan6
+玉 juk6
+秀 sau3
+安 on1
+寶宝 bou2
+祖 zou2
+賢贤 jin4
+朝 ciu4 ziu1
+棟栋 dung6
+建 gin3
+新 san1
+民 man4
+光 gwong1
+永 wing5
+健 gin6
+俊 zeon3
+傑杰 git6
+雄 hung4
+峰 fung1
+東东 dung1
+南 naam4
+西 sai1
+北 bak1
+中 zung1
+山 saan1
+水 seoi2
+金 gam1
+木 muk6
+火 fo2
+土 tou2
+大 daai6
+一 jat1
+二 ji6
+三 saam1
+四 sei3
+五 ng5
+六 luk6
+七 cat1
+八 baat3
+九 gau2
+十 sap6
+百 baak3
+千 cin1
+仔 zai2
+之 zi1
+和 wo4
+平 ping4
+仁 jan4
+義义 ji6
+禮礼 lai5
+信 seon3
+智 zi3
+忠 zung1
+愛爱 oi3
+心 sam1
+思 si1
+恩 jan1
+惠 wai6
+珍 zan1
+珠 zyu1
+翠 ceoi3
+紅红 hung4
+綠绿 luk6
+青 cing1
+白 baak6
+黑 hak1
+春 ceon1
+秋 cau1
+冬 dung1
+夏 haa6
+雲云 wan4
+雪 syut3
+風风 fung1
+雨 jyu5
+月 jyut6
+日 jat6
+海 hoi2
+港 gong2
+島岛 dou2
+灣湾 waan1
+角 gok3
+旺 wong6
+仙 sin1
+沙 saa1
+尖 zim1
+咀 zeoi2
+銅铜 tung4
+鑼锣 lo4
+深 sam1
+埗 bou6
+荃 cyun4
+屯 tyun4
+門门 mun4
+元 jyun4
+朗 long5
+葵 kwai4
+涌 cung1
+觀观 gun1
+塘 tong4
+將将 zoeng1 zoeng3
+軍军 gwan1
+澳 ou3
+香 hoeng1
+廣广 gwong2
+州 zau1
+佛 fat6
+圳 zan3
+地 dei6
+上 soeng6 soeng5
+環环 waan4
+街 gaai1
+道 dou6
+路 lou6
+市 si5
+村 cyun1
+園园 jyun4
+臺台 toi4
+界 gaai3
+油 jau4
+麻 maa4
+彌弥 nei4
+敦 deon1
+啟启 kai2
+機机 gei1
+場场 coeng4
+太 taai3
+坑 haang1
+柴 caai4
+鯉鲤 lei5
+魚鱼 jyu4
+筲 saau1
+箕 gei1
+淺浅 cin2
+赤 cek3
+柱 cyu5
+鴨鸭 aap3
+脷 lei6
+洲 zau1
+長长 coeng4 zoeng2
+坪 ping4
+頭头 tau4
+茶 caa4
+餐 caan1
+廳厅 teng1
+點点 dim2
+飲饮 jam2
+粵粤 jyut6
+語语 jyu5
+音 jam1
+拼 ping3
+人 jan4
+好 hou2
+唔 m4
+係系 hai6
+我 ngo5
+你 nei5
+佢 keoi5
+嘅 ge3
+牛 ngau4
+顏颜 ngaan4
+雅 ngaa5
+岳 ngok6
+艾 ngaai6
+敖 ngou4
+洋 joeng4
+陽阳 joeng4
+羊 joeng4
+業业 jip6
+言 jin4
+然 jin4
+燕 jin3
+英 jing1
+影 jing2
+衛卫 wai6
+蘭兰 laan4
+鳳凤 fung6
+鳴鸣 ming4
+熙 hei1
+希 hei1
+喜 hei2
+凱凯 hoi2
+開开 hoi1
+來来 loi4
+豪 hou4
+浩 hou6
+康 hong1
+樂乐 lok6 ngok6
+福 fuk1
+祿禄 luk6
+壽寿 sau6
+財财 coi4
+貴贵 gwai3
+桂 gwai3
+維维 wai4
+慈 ci4
+雯 man4
+芬 fan1
+君 gwan1
+群 kwan4
+坤 kwan1
+權权 kyun4
+誠诚 sing4
+鋒锋 fung1
+兆 siu6
+基 gei1
+奇 kei4
+琪 kei4
+麒 kei4
+麟 leon4
+霆 ting4
+行 hang4 hong4
+重 cung4 zung6
+`
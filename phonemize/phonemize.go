@@ -0,0 +1,29 @@
+/*
+Package phonemize defines the types shared by Hangulize and the phonemizers.
+
+A phonemizer package can't import the hangulize package because the hangulize
+package imports the phonemizers in its tests. Instead, both of them import
+this package.
+*/
+package phonemize
+
+// Segment is a part of a word with the phonograms guessed by a phonemizer.
+type Segment struct {
+	Surface string // The original spelling, such as "新海".
+	Reading string // The phonograms, such as "シンカイ".
+	Note    string // Why the reading was chosen, such as "personName".
+}
+
+// WordClassOption is the option key for the word class of the word to
+// phonemize. A phonemizer may read the word differently by the class. The
+// value is one of the word classes below.
+const WordClassOption = "wordclass"
+
+// Word classes.
+const (
+	CommonNoun = "common"
+	PersonName = "person"     // A full name.
+	GivenName  = "given-name" // Only a given name.
+	Surname    = "surname"    // Only a surname.
+	PlaceName  = "place"
+)
@@ -0,0 +1,244 @@
+package pinyin
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// PhraseTable maps phrases to the readings of each Hanzu. A phrase fixes the
+// readings of heteronyms (多音字) which depend on the context, such as "行" in
+// "银行" (hang) and "行人" (xing).
+type PhraseTable struct {
+	mu      sync.RWMutex
+	phrases map[string][]string
+	maxLen  int
+}
+
+// NewPhraseTable creates an empty phrase table.
+func NewPhraseTable() *PhraseTable {
+	return &PhraseTable{phrases: make(map[string][]string)}
+}
+
+// Add adds a phrase with the Pinyin of each Hanzu. The number of the readings
+// should be the same with the number of the Hanzu in the phrase.
+func (t *PhraseTable) Add(phrase string, readings ...string) error {
+	n := utf8.RuneCountInString(phrase)
+
+	if n == 0 || n != len(readings) {
+		return fmt.Errorf("invalid phrase: %#v %v", phrase, readings)
+	}
+
+	for _, reading := range readings {
+		if reading == "" || strings.ContainsAny(reading, " \t\r\n") {
+			return fmt.Errorf("invalid reading of %#v: %#v", phrase, reading)
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.phrases[phrase] = readings
+	if n > t.maxLen {
+		t.maxLen = n
+	}
+	return nil
+}
+
+// Len returns the number of phrases.
+func (t *PhraseTable) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return len(t.phrases)
+}
+
+// lookup finds the readings of a phrase. It is safe with a nil table.
+func (t *PhraseTable) lookup(phrase string) ([]string, bool) {
+	if t == nil {
+		return nil, false
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	readings, ok := t.phrases[phrase]
+	return readings, ok
+}
+
+// longest returns the length of the longest phrase in runes.
+func (t *PhraseTable) longest() int {
+	if t == nil {
+		return 0
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.maxLen
+}
+
+// ReadPhraseTable reads a phrase table. Each line has a phrase and the Pinyin
+// of each Hanzu separated by spaces. Empty lines and lines starting with "#"
+// are ignored:
+//
+//   # phrase pinyin...
+//   重庆 chong qing
+//   银行 yin hang
+//
+func ReadPhraseTable(r io.Reader) (*PhraseTable, error) {
+	t := NewPhraseTable()
+	scanner := bufio.NewScanner(r)
+
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("phrase table line %d: %#v", lineNo, line)
+		}
+
+		err := t.Add(fields[0], fields[1:]...)
+		if err != nil {
+			return nil, fmt.Errorf("phrase table line %d: %s", lineNo, err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// LoadPhraseTable reads a phrase table file. See ReadPhraseTable for the
+// format.
+func LoadPhraseTable(path string) (*PhraseTable, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadPhraseTable(f)
+}
+
+// ----------------------------------------------------------------------------
+
+// bundledPhrases is the phrase table which every Phonemizer uses. It is a
+// small override table, not a dictionary for word segmentation. It covers
+// only some frequent heteronyms in place names, person names and common
+// words. A phrase is written in both the simplified and traditional Hanzu if
+// they differ.
+var bundledPhrases = mustReadPhraseTable(`
+# Place names
+重庆 chong qing
+重慶 chong qing
+长沙 chang sha
+長沙 chang sha
+长春 chang chun
+長春 chang chun
+长城 chang cheng
+長城 chang cheng
+长江 chang jiang
+長江 chang jiang
+成都 cheng du
+厦门 xia men
+廈門 xia men
+朝阳 chao yang
+朝陽 chao yang
+朝鲜 chao xian
+朝鮮 chao xian
+蚌埠 beng bu
+六安 lu an
+乐山 le shan
+樂山 le shan
+单县 shan xian
+單縣 shan xian
+丽水 li shui
+麗水 li shui
+台州 tai zhou
+番禺 pan yu
+东莞 dong guan
+東莞 dong guan
+大连 da lian
+大連 da lian
+石家庄 shi jia zhuang
+石家莊 shi jia zhuang
+
+# Person names
+曾国藩 zeng guo fan
+曾國藩 zeng guo fan
+单田芳 shan tian fang
+單田芳 shan tian fang
+解缙 xie jin
+解縉 xie jin
+尉迟 yu chi
+尉遲 yu chi
+万俟 mo qi
+萬俟 mo qi
+
+# Common words
+银行 yin hang
+銀行 yin hang
+行长 hang zhang
+行長 hang zhang
+行业 hang ye
+行業 hang ye
+行人 xing ren
+行为 xing wei
+行為 xing wei
+重要 zhong yao
+重量 zhong liang
+重复 chong fu
+重複 chong fu
+重新 chong xin
+音乐 yin yue
+音樂 yin yue
+快乐 kuai le
+快樂 kuai le
+大厦 da sha
+大廈 da sha
+首都 shou du
+都市 du shi
+曾经 ceng jing
+曾經 ceng jing
+觉得 jue de
+覺得 jue de
+睡觉 shui jiao
+睡覺 shui jiao
+会计 kuai ji
+會計 kuai ji
+传记 zhuan ji
+傳記 zhuan ji
+校长 xiao zhang
+校長 xiao zhang
+市长 shi zhang
+市長 shi zhang
+长大 zhang da
+長大 zhang da
+和平 he ping
+暖和 nuan huo
+还是 hai shi
+還是 hai shi
+还原 huan yuan
+還原 huan yuan
+`)
+
+func mustReadPhraseTable(table string) *PhraseTable {
+	t, err := ReadPhraseTable(strings.NewReader(table))
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
@@ -2,49 +2,275 @@
 Package pinyin implements the hangulize.Phonemizer interface for Chinese
 Hanzu. Hanzu has very broad characters so they need a dictionary to be
 converted to a phonogram.
+
+Some Hanzu have several readings by the context, such as "重" in "重庆"
+(chong) and "重要" (zhong). The phonemizer doesn't segment words by a
+dictionary. Instead, it overrides the readings of the phrases in a small
+table. The bundled table covers only some frequent heteronyms in both the
+simplified and traditional Hanzu. A user phrase table, such as a larger one
+loaded by LoadPhraseTable, overrides the bundled one:
+
+	phrases := pinyin.NewPhraseTable()
+	phrases.Add("单田芳", "shan", "tian", "fang")
+
+	h := hangulize.NewHangulizer(spec)
+	h.UsePhonemizer(pinyin.New(pinyin.WithPhrases(phrases)))
 */
 package pinyin
 
 import (
 	"bytes"
+	"context"
+	"fmt"
 	"strings"
 
 	goPinyin "github.com/mozillazg/go-pinyin"
+
+	"github.com/hangulize/hangulize/phonemize"
 )
 
 // P is the Pinyin phonemizer.
-var P pinyinPhonemizer
+var P Phonemizer
+
+// HeteronymOption is the PhonemizeContext option key to collect every reading
+// of heteronyms. The value is a bool. The alternatives don't change the
+// result but they appear in the segments and tokens:
+//
+//   重: zhong (zhong/chong)
+//
+const HeteronymOption = "pinyin.heteronym"
+
+// Token is a Hanzu or a chunk of the other characters with the reading.
+type Token struct {
+	Surface string // The original spelling.
+	Reading string // The Pinyin without tone.
+
+	// Phrase is the phrase which decided the reading. It is empty if the
+	// reading is the most common one of the Hanzu.
+	Phrase string
+
+	// Heteronyms is every reading of the Hanzu. It is collected only with
+	// HeteronymOption.
+	Heteronyms []string
+
+	// Known is false for a chunk which is not a Hanzu. The reading of it is
+	// just the surface.
+	Known bool
+}
 
 // ----------------------------------------------------------------------------
 
-type pinyinPhonemizer struct{}
+// Phonemizer is the Pinyin phonemizer. The zero value is ready to use with
+// the bundled phrase table. Use New to customize it.
+type Phonemizer struct {
+	phrases *PhraseTable
+}
+
+// Option customizes a Phonemizer.
+type Option func(*Phonemizer)
+
+// WithPhrases makes a Phonemizer prefer the readings in the phrase table to
+// the bundled ones.
+func WithPhrases(phrases *PhraseTable) Option {
+	return func(p *Phonemizer) {
+		p.phrases = phrases
+	}
+}
 
-func (pinyinPhonemizer) ID() string {
+// New creates a Phonemizer. Unlike the global P, it can be used only for a
+// Hangulizer:
+//
+//   h.UsePhonemizer(pinyin.New(pinyin.WithPhrases(phrases)))
+//
+func New(opts ...Option) *Phonemizer {
+	p := &Phonemizer{}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// ID returns "pinyin".
+func (*Phonemizer) ID() string {
 	return "pinyin"
 }
 
-func (p *pinyinPhonemizer) Phonemize(word string) string {
-	var chunks []string
+// Phonemize converts Hanzu in a word to Pinyin. Each Hanzu is separated by
+// ZWSP.
+func (p *Phonemizer) Phonemize(word string) string {
+	var buf bytes.Buffer
+	for _, seg := range p.segments(p.analyze(word, false)) {
+		buf.WriteString(seg.Reading)
+	}
+	return buf.String()
+}
+
+// Analyze returns the Hanzu and the other chunks in a word with the
+// readings.
+func (p *Phonemizer) Analyze(word string) []Token {
+	tokens, _ := p.AnalyzeContext(context.Background(), word, nil)
+	return tokens
+}
+
+// AnalyzeContext is Analyze with a context and options. The options are the
+// same with PhonemizeSegments.
+func (p *Phonemizer) AnalyzeContext(
+	ctx context.Context,
+	word string,
+	opts map[string]interface{},
+) ([]Token, error) {
+	heteronym := false
+
+	if opt, ok := opts[HeteronymOption]; ok {
+		b, ok := opt.(bool)
+		if !ok {
+			return nil, fmt.Errorf("unknown %s: %v", HeteronymOption, opt)
+		}
+		heteronym = b
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return p.analyze(word, heteronym), nil
+}
+
+// PhonemizeSegments implements hangulize.SegmentPhonemizer. Each Hanzu is a
+// segment. The note of a segment tells how the reading is chosen.
+func (p *Phonemizer) PhonemizeSegments(
+	ctx context.Context,
+	word string,
+	opts map[string]interface{},
+) ([]phonemize.Segment, error) {
+	tokens, err := p.AnalyzeContext(ctx, word, opts)
+	if err != nil {
+		return nil, err
+	}
+	return p.segments(tokens), nil
+}
+
+// analyze finds the longest phrases in the override tables from the head of a
+// word. Hanzu out of any phrase take the most common readings.
+func (p *Phonemizer) analyze(word string, heteronym bool) []Token {
+	var tokens []Token
 	var buf bytes.Buffer
 
 	a := goPinyin.NewArgs()
+	a.Heteronym = heteronym
+
+	flush := func() {
+		if buf.Len() != 0 {
+			chunk := buf.String()
+			tokens = append(tokens, Token{Surface: chunk, Reading: chunk})
+			buf.Reset()
+		}
+	}
+
+	chars := []rune(word)
+
+	for i := 0; i < len(chars); {
+		phrase, readings := p.matchPhrase(chars[i:])
+
+		if phrase != nil {
+			flush()
+
+			for j, ch := range phrase {
+				tok := Token{Surface: string(ch), Reading: readings[j], Known: true}
+				if len(phrase) > 1 {
+					tok.Phrase = string(phrase)
+				}
+				if heteronym {
+					tok.Heteronyms = goPinyin.SinglePinyin(ch, a)
+				}
+				tokens = append(tokens, tok)
+			}
+
+			i += len(phrase)
+			continue
+		}
+
+		ch := chars[i]
+		i++
 
-	for _, ch := range word {
 		pyn := goPinyin.SinglePinyin(ch, a)
 
 		if len(pyn) == 0 {
 			buf.WriteRune(ch)
-		} else {
-			if buf.Len() != 0 {
-				chunks = append(chunks, buf.String())
-				buf.Reset()
-			}
-			chunks = append(chunks, pyn[0])
+			continue
 		}
+
+		flush()
+
+		tok := Token{Surface: string(ch), Reading: pyn[0], Known: true}
+		if heteronym {
+			tok.Heteronyms = pyn
+		}
+		tokens = append(tokens, tok)
 	}
-	if buf.Len() != 0 {
-		chunks = append(chunks, buf.String())
+	flush()
+
+	return tokens
+}
+
+// matchPhrase finds the longest phrase at the head of the characters. The
+// user phrase table is preferred. Only the user phrase table may have a
+// single Hanzu phrase.
+func (p *Phonemizer) matchPhrase(chars []rune) ([]rune, []string) {
+	n := p.phrases.longest()
+	if m := bundledPhrases.longest(); m > n {
+		n = m
+	}
+	if n > len(chars) {
+		n = len(chars)
+	}
+
+	for ; n >= 1; n-- {
+		phrase := chars[:n]
+
+		if readings, ok := p.phrases.lookup(string(phrase)); ok {
+			return phrase, readings
+		}
+
+		if n == 1 {
+			break
+		}
+
+		if readings, ok := bundledPhrases.lookup(string(phrase)); ok {
+			return phrase, readings
+		}
+	}
+
+	return nil, nil
+}
+
+// segments converts tokens to segments. The segments are separated by ZWSP.
+func (p *Phonemizer) segments(tokens []Token) []phonemize.Segment {
+	var segs []phonemize.Segment
+
+	for _, tok := range tokens {
+		if len(segs) != 0 {
+			segs = append(segs, phonemize.Segment{Reading: "\u200b"})
+		}
+
+		note := "kept"
+		if tok.Known {
+			note = "pinyin"
+
+			if tok.Phrase != "" {
+				note += ", phrase " + tok.Phrase
+			}
+			if len(tok.Heteronyms) > 1 {
+				note += ", heteronym " + strings.Join(tok.Heteronyms, "/")
+			}
+		}
+
+		segs = append(segs, phonemize.Segment{
+			Surface: tok.Surface,
+			Reading: tok.Reading,
+			Note:    note,
+		})
 	}
 
-	return strings.Join(chunks, "\u200b")
+	return segs
 }
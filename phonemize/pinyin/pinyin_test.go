@@ -1,16 +1,109 @@
 package pinyin
 
 import (
+	"context"
+	"strings"
 	"testing"
 
 	"github.com/hangulize/hangulize"
+	"github.com/hangulize/hangulize/phonemize"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestPhonemizer(t *testing.T) {
 	assert.Implements(t, (*hangulize.Phonemizer)(nil), &P)
+	assert.Implements(t, (*hangulize.SegmentPhonemizer)(nil), &P)
 }
 
 func TestPinyin(t *testing.T) {
 	assert.Equal(t, "pin\u200byin", P.Phonemize("拼音"))
 }
+
+func TestPinyinKept(t *testing.T) {
+	assert.Equal(t, "ab\u200bpin\u200b!", P.Phonemize("ab拼!"))
+}
+
+func TestPhonemizeSegments(t *testing.T) {
+	segs, err := P.PhonemizeSegments(context.Background(), "拼音!", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []phonemize.Segment{
+		{Surface: "拼", Reading: "pin", Note: "pinyin"},
+		{Reading: "\u200b"},
+		{Surface: "音", Reading: "yin", Note: "pinyin"},
+		{Reading: "\u200b"},
+		{Surface: "!", Reading: "!", Note: "kept"},
+	}, segs)
+}
+
+func TestPhrases(t *testing.T) {
+	assert.Equal(t, "chong\u200bqing", P.Phonemize("重庆"))
+	assert.Equal(t, "yin\u200bhang", P.Phonemize("银行"))
+
+	// The traditional spellings.
+	assert.Equal(t, "chong\u200bqing", P.Phonemize("重慶"))
+	assert.Equal(t, "yin\u200bhang", P.Phonemize("銀行"))
+	assert.Equal(t, "chang\u200bcheng", P.Phonemize("長城"))
+
+	// Out of the phrases.
+	assert.Equal(t, "zhong", P.Phonemize("重"))
+	assert.Equal(t, "xing", P.Phonemize("行"))
+}
+
+func TestUserPhrases(t *testing.T) {
+	phrases := NewPhraseTable()
+	assert.NoError(t, phrases.Add("银行", "yin", "xing"))
+	assert.NoError(t, phrases.Add("重", "chong"))
+
+	p := New(WithPhrases(phrases))
+	assert.Equal(t, "yin\u200bxing", p.Phonemize("银行"))
+	assert.Equal(t, "chong", p.Phonemize("重"))
+
+	// The bundled phrases still work.
+	assert.Equal(t, "chong\u200bqing", p.Phonemize("重庆"))
+}
+
+func TestPhraseTableInvalid(t *testing.T) {
+	phrases := NewPhraseTable()
+	assert.Error(t, phrases.Add("重庆", "chong"))
+	assert.Error(t, phrases.Add("", ""))
+	assert.Error(t, phrases.Add("重", "chong qing"))
+
+	_, err := ReadPhraseTable(strings.NewReader("重庆"))
+	assert.Error(t, err)
+}
+
+func TestReadPhraseTable(t *testing.T) {
+	phrases, err := ReadPhraseTable(strings.NewReader(`
+	# phrase pinyin...
+	重庆 chong qing
+
+	银行  yin  hang
+	`))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, phrases.Len())
+
+	readings, ok := phrases.lookup("银行")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"yin", "hang"}, readings)
+}
+
+func TestHeteronym(t *testing.T) {
+	opts := map[string]interface{}{HeteronymOption: true}
+
+	tokens, err := P.AnalyzeContext(context.Background(), "重庆行", opts)
+	assert.NoError(t, err)
+	assert.Equal(t, []Token{
+		{"重", "chong", "重庆", []string{"zhong", "chong"}, true},
+		{"庆", "qing", "重庆", []string{"qing"}, true},
+		{"行", "xing", "", []string{"xing", "hang"}, true},
+	}, tokens)
+
+	segs, err := P.PhonemizeSegments(context.Background(), "行", opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "pinyin, heteronym xing/hang", segs[0].Note)
+
+	_, err = P.AnalyzeContext(context.Background(), "行", map[string]interface{}{
+		HeteronymOption: "yes",
+	})
+	assert.Error(t, err)
+}
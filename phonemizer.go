@@ -1,5 +1,19 @@
 package hangulize
 
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/hangulize/hangulize/phonemize"
+)
+
+// ErrPhonemizerMissing is the cause of the error when the phonemizer which the
+// spec requires is not imported. Hangulize leaves the word unphonemized but
+// HangulizeContext reports the error. The result is never cached.
+var ErrPhonemizerMissing = errors.New("phonemizer missing")
+
 // Phonemizer is an interface to guess phonograms from a spelling based on
 // lexical analysis.
 //
@@ -20,6 +34,49 @@ type Phonemizer interface {
 	Phonemize(string) string
 }
 
+// ContextPhonemizer is an extended Phonemizer. It can be cancelled by a
+// context, can report a failure, and can receive per-call options such as a
+// reading preference.
+//
+// The option keys are defined by each phonemizer. The options are passed as
+// a plain map so that a phonemizer package doesn't need to import this
+// package.
+//
+// The pipeline prefers PhonemizeContext if a phonemizer implements it, unless
+// the phonemizer implements SegmentPhonemizer too.
+type ContextPhonemizer interface {
+	Phonemizer
+	PhonemizeContext(
+		ctx context.Context,
+		word string,
+		opts map[string]interface{},
+	) (string, error)
+}
+
+// SegmentPhonemizer is an extended Phonemizer which can explain how it
+// phonemized a word segment by segment. The readings of the segments should
+// make the phonemized word.
+//
+// The pipeline prefers PhonemizeSegments if a phonemizer implements it, with
+// or without tracing. Each segment is traced in the "phonemize" step.
+type SegmentPhonemizer interface {
+	Phonemizer
+	PhonemizeSegments(
+		ctx context.Context,
+		word string,
+		opts map[string]interface{},
+	) ([]phonemize.Segment, error)
+}
+
+// joinReadings concatenates the readings of segments.
+func joinReadings(segs []phonemize.Segment) string {
+	var buf bytes.Buffer
+	for _, seg := range segs {
+		buf.WriteString(seg.Reading)
+	}
+	return buf.String()
+}
+
 // usePhonemizer keeps a phonemizer into the given registry.
 func usePhonemizer(p Phonemizer, phonemizers *map[string]Phonemizer) bool {
 	id := p.ID()
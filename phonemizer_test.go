@@ -1,10 +1,14 @@
 package hangulize
 
 import (
+	"context"
 	"testing"
 
+	"github.com/hangulize/hangulize/phonemize"
 	"github.com/hangulize/hangulize/phonemize/furigana"
+	"github.com/hangulize/hangulize/phonemize/jyutping"
 	"github.com/hangulize/hangulize/phonemize/pinyin"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -14,6 +18,7 @@ import (
 func init() {
 	UsePhonemizer(&furigana.P)
 	UsePhonemizer(&pinyin.P)
+	UsePhonemizer(&jyutping.P)
 }
 
 // -----------------------------------------------------------------------------
@@ -56,3 +61,142 @@ func TestPhonemizerRegistry(t *testing.T) {
 	ok = UnusePhonemizer("my")
 	assert.False(t, ok)
 }
+
+// -----------------------------------------------------------------------------
+
+type ctxPhonemizer struct{}
+
+func (ctxPhonemizer) ID() string {
+	return "ctx"
+}
+
+func (ctxPhonemizer) Phonemize(word string) string {
+	return word
+}
+
+func (ctxPhonemizer) PhonemizeContext(
+	ctx context.Context,
+	word string,
+	opts map[string]interface{},
+) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return word, err
+	}
+	if opts["fail"] == true {
+		return word, errors.New("failed")
+	}
+	if suffix, ok := opts["suffix"].(string); ok {
+		word += suffix
+	}
+	return word, nil
+}
+
+var ctxSpec = mustParseSpec(`
+lang:
+	id         = "ctx"
+	codes      = "", ""
+	phonemizer = "ctx"
+
+transcribe:
+	"a" -> "ㅏ"
+	"b" -> "ㅂ"
+`)
+
+func TestContextPhonemizer(t *testing.T) {
+	h := NewHangulizer(ctxSpec, WithPhonemizeOption("suffix", "b"))
+	h.UsePhonemizer(&ctxPhonemizer{})
+
+	assert.Equal(t, "아브", h.Hangulize("a"))
+
+	word, err := h.HangulizeContext(context.Background(), "a")
+	assert.NoError(t, err)
+	assert.Equal(t, "아브", word)
+}
+
+func TestContextPhonemizerFailure(t *testing.T) {
+	h := NewHangulizer(ctxSpec, WithPhonemizeOption("fail", true), WithCache(10))
+	h.UsePhonemizer(&ctxPhonemizer{})
+
+	// Hangulize ignores the failure.
+	assert.Equal(t, "아", h.Hangulize("a"))
+	assert.Equal(t, 0, h.CacheStats().Len)
+
+	// HangulizeContext reports it with the same result.
+	word, err := h.HangulizeContext(context.Background(), "a")
+	assert.Error(t, err)
+	assert.Equal(t, "아", word)
+}
+
+func TestContextPhonemizerCancel(t *testing.T) {
+	h := NewHangulizer(ctxSpec)
+	h.UsePhonemizer(&ctxPhonemizer{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	word, err := h.HangulizeContext(ctx, "a")
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, "", word)
+}
+
+// -----------------------------------------------------------------------------
+
+type segPhonemizer struct {
+	ctxPhonemizer
+}
+
+func (segPhonemizer) PhonemizeSegments(
+	ctx context.Context,
+	word string,
+	opts map[string]interface{},
+) ([]phonemize.Segment, error) {
+	return []phonemize.Segment{
+		{Surface: "A", Reading: "a", Note: "known"},
+		{Surface: "", Reading: " ", Note: "separator"},
+		{Surface: "?", Reading: "?", Note: "unknown"},
+		{Surface: "B", Reading: "b", Note: "known"},
+	}, nil
+}
+
+func TestSegmentPhonemizerTrace(t *testing.T) {
+	h := NewHangulizer(ctxSpec)
+	h.UsePhonemizer(&segPhonemizer{})
+
+	word, tr := h.HangulizeTrace("A?B")
+	assert.Equal(t, "아 ?브", word)
+
+	assert.Equal(t, Trace{"phonemize", `"A" -> "a" (known)`, "a?B"}, tr[1])
+	assert.Equal(t, Trace{"phonemize", `"?" -> "?" (unknown)`, "a ?B"}, tr[2])
+	assert.Equal(t, Trace{"phonemize", `"B" -> "b" (known)`, "a ?b"}, tr[3])
+
+	// Without tracing, the segments are used too.
+	assert.Equal(t, word, h.Hangulize("A?B"))
+
+	result, err := h.HangulizeContext(context.Background(), "A?B")
+	assert.NoError(t, err)
+	assert.Equal(t, word, result)
+}
+
+func TestPhonemizerMissing(t *testing.T) {
+	h := NewHangulizer(ctxSpec, WithCache(10))
+
+	// The word is left unphonemized and not cached.
+	assert.Equal(t, "아브", h.Hangulize("ab"))
+	assert.Equal(t, 0, h.CacheStats().Len)
+
+	word, err := h.HangulizeContext(context.Background(), "ab")
+	assert.Equal(t, ErrPhonemizerMissing, errors.Cause(err))
+	assert.Equal(t, "아브", word)
+
+	_, tr := h.HangulizeTrace("ab")
+	assert.Equal(t, Trace{"phonemize", `"ctx" missing`, "ab"}, tr[1])
+
+	// The global phonemizer imported later is used.
+	UsePhonemizer(&ctxPhonemizer{})
+	defer UnusePhonemizer("ctx")
+
+	word, err = h.HangulizeContext(context.Background(), "ab")
+	assert.NoError(t, err)
+	assert.Equal(t, "아브", word)
+	assert.Equal(t, 1, h.CacheStats().Len)
+}
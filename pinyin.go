@@ -0,0 +1,210 @@
+package hangulize
+
+import (
+	"bytes"
+	"strings"
+)
+
+// pinyinSyllables is the set of the Pinyin syllables without tones. "ü" is
+// written as "v".
+var pinyinSyllables = makeSyllables(`
+	a o e ai ei ao ou an en ang eng er
+	yi ya yo ye yao you yan yin yang ying yong
+	wu wa wo wai wei wan wen wang weng
+	yu yue yuan yun
+	ba bo bai bei bao ban ben bang beng bi biao bie bian bin bing bu
+	pa po pai pei pao pou pan pen pang peng pi piao pie pian pin ping pu
+	ma mo me mai mei mao mou man men mang meng
+	mi miao mie miu mian min ming mu
+	fa fo fei fou fan fen fang feng fu
+	da de dai dei dao dou dan den dang deng dong
+	di dia diao die diu dian ding du duo dui duan dun
+	ta te tai tao tou tan tang teng tong
+	ti tiao tie tian ting tu tuo tui tuan tun
+	na ne nai nei nao nou nan nen nang neng nong
+	ni niao nie niu nian nin niang ning nu nuo nuan nv nve nue
+	la lo le lai lei lao lou lan lang leng long
+	li lia liao lie liu lian lin liang ling lu luo luan lun lv lve lue
+	ga ge gai gei gao gou gan gen gang geng gong
+	gu gua guo guai gui guan gun guang
+	ka ke kai kei kao kou kan ken kang keng kong
+	ku kua kuo kuai kui kuan kun kuang
+	ha he hai hei hao hou han hen hang heng hong
+	hu hua huo huai hui huan hun huang
+	ji jia jiao jie jiu jian jin jiang jing jiong ju jue juan jun
+	qi qia qiao qie qiu qian qin qiang qing qiong qu que quan qun
+	xi xia xiao xie xiu xian xin xiang xing xiong xu xue xuan xun
+	zha zhe zhi zhai zhei zhao zhou zhan zhen zhang zheng zhong
+	zhu zhua zhuo zhuai zhui zhuan zhun zhuang
+	cha che chi chai chao chou chan chen chang cheng chong
+	chu chua chuo chuai chui chuan chun chuang
+	sha she shi shai shei shao shou shan shen shang sheng
+	shu shua shuo shuai shui shuan shun shuang
+	re ri rao rou ran ren rang reng rong ru rua ruo rui ruan run
+	za ze zi zai zei zao zou zan zen zang zeng zong zu zuo zui zuan zun
+	ca ce ci cai cao cou can cen cang ceng cong cu cuo cui cuan cun
+	sa se si sai sao sou san sen sang seng song su suo sui suan sun
+`)
+
+// maxPinyinSyllable is the length of the longest Pinyin syllable, such as
+// "zhuang".
+const maxPinyinSyllable = 6
+
+// makeSyllables makes a set of the syllables separated by spaces.
+func makeSyllables(table string) map[string]bool {
+	syllables := make(map[string]bool)
+	for _, syllable := range strings.Fields(table) {
+		syllables[syllable] = true
+	}
+	return syllables
+}
+
+// segmentPinyin splits run-together Pinyin into syllables. The letters should
+// be normalized already. It returns the length of each syllable. If the
+// letters are not Pinyin, it returns nil.
+//
+// A syllable starting with "a", "o" or "e" never follows another syllable
+// without an apostrophe. So "xian" is one syllable and "fangan" is "fan"
+// and "gan":
+//
+//   xian   -> xian
+//   fangan -> fan gan
+//
+func segmentPinyin(letters []rune) []int {
+	// fails[i] means that letters[i:] can't be segmented.
+	fails := make([]bool, len(letters)+1)
+
+	var segment func(i int) []int
+	segment = func(i int) []int {
+		if i == len(letters) {
+			return []int{}
+		}
+		if fails[i] {
+			return nil
+		}
+
+		// An apostrophe is required before "a", "o" and "e".
+		if i != 0 {
+			switch letters[i] {
+			case 'a', 'o', 'e':
+				fails[i] = true
+				return nil
+			}
+		}
+
+		// Prefer the longest syllable.
+		n := maxPinyinSyllable
+		if n > len(letters)-i {
+			n = len(letters) - i
+		}
+
+		for ; n > 0; n-- {
+			if !pinyinSyllables[string(letters[i:i+n])] {
+				continue
+			}
+
+			if rest := segment(i + n); rest != nil {
+				return append([]int{n}, rest...)
+			}
+		}
+
+		fails[i] = true
+		return nil
+	}
+
+	return segment(0)
+}
+
+// normalizePinyinWord marks the syllable boundaries in Pinyin with ZWSP.
+// The boundaries come from tone numbers, apostrophes and the syllable table:
+//
+//   bei3jing1 -> bei jing
+//   Xi'an     -> Xi an
+//   Beijing   -> Bei jing
+//
+// It also converts "u:", the ASCII form of "ü", to "ü":
+//
+//   lu:4 -> lü
+//
+func normalizePinyinWord(s *_Pinyin, word string) string {
+	var buf bytes.Buffer
+	var run []rune
+
+	// boundary is true when a syllable has been finished by a tone number or
+	// an apostrophe.
+	boundary := false
+
+	// segment segments the current run of letters.
+	segment := func() []int {
+		letters := make([]rune, len(run))
+		for i, ch := range run {
+			letters[i] = s.Normalize(ch)
+		}
+		return segmentPinyin(letters)
+	}
+
+	flush := func() {
+		if len(run) == 0 {
+			return
+		}
+
+		lens := segment()
+		if lens == nil {
+			// Not Pinyin. Keep it as is.
+			buf.WriteString(string(run))
+		} else {
+			i := 0
+			for j, n := range lens {
+				if j != 0 {
+					buf.WriteRune('\u200B')
+				}
+				buf.WriteString(string(run[i : i+n]))
+				i += n
+			}
+		}
+
+		run = run[:0]
+	}
+
+	chars := []rune(word)
+
+	for i, ch := range chars {
+		afterLetter := len(run) != 0
+		beforeLetter := i+1 < len(chars) && s.Is(chars[i+1])
+
+		switch {
+
+		case s.Is(ch):
+			if boundary {
+				buf.WriteRune('\u200B')
+				boundary = false
+			}
+			run = append(run, ch)
+
+		case ch == ':' && afterLetter && (run[len(run)-1] == 'u' || run[len(run)-1] == 'U'):
+			if run[len(run)-1] == 'u' {
+				run[len(run)-1] = 'ü'
+			} else {
+				run[len(run)-1] = 'Ü'
+			}
+
+		case '0' <= ch && ch <= '5' && afterLetter && segment() != nil:
+			// A tone number finishes a syllable.
+			flush()
+			boundary = true
+
+		case (ch == '\'' || ch == '’') && afterLetter && beforeLetter:
+			flush()
+			boundary = true
+
+		default:
+			flush()
+			boundary = false
+			buf.WriteRune(ch)
+
+		}
+	}
+	flush()
+
+	return buf.String()
+}
@@ -2,9 +2,13 @@ package hangulize
 
 import (
 	"bytes"
+	"context"
+	"fmt"
 	"regexp"
 	"strings"
 	"unicode"
+
+	"github.com/pkg/errors"
 )
 
 var reSpace = regexp.MustCompile(`\s`)
@@ -20,16 +24,74 @@ func hasSpace(word string) bool {
 // -----------------------------------------------------------------------------
 
 type pipeline struct {
-	h  *Hangulizer
-	tr *tracer
+	h   *Hangulizer
+	tr  *tracer
+	s   *scratch
+	ctx context.Context
+
+	// The first error while transcribing, such as an OverlapError.
+	err error
+}
+
+// scratch keeps the buffers which are used in the pipeline steps. A pipeline
+// reuses them while it transcribes many words to avoid allocations.
+type scratch struct {
+	buf     bytes.Buffer
+	jamoBuf bytes.Buffer
+
+	chars   []rune
+	isPunct []bool
+	isSpace []bool
+}
+
+// scratch returns the buffers for the pipeline. It prepares them at the first
+// call.
+func (p *pipeline) scratch() *scratch {
+	if p.s == nil {
+		p.s = &scratch{}
+	}
+	return p.s
+}
+
+// context returns the context for the pipeline. It is never nil.
+func (p *pipeline) context() context.Context {
+	if p.ctx == nil {
+		return context.Background()
+	}
+	return p.ctx
 }
 
 // forward runs the Hangulize pipeline for a word.
-func (p *pipeline) forward(word string) string {
+//
+// Even if the phonemizer fails, it keeps going with the unphonemized word and
+// returns the error together. Only when the context is done, it stops
+// immediately. It also reports Hangul in the input not kept in the result by
+// the Hangul policy and overlapping matches by the overlap policy.
+func (p *pipeline) forward(word string) (string, error) {
+	original := word
 	p.input(word)
 
 	// preparing phase
-	word, _ = p.phonemize(word)
+	word, err := p.phonemize(word)
+	if ctxErr := p.context().Err(); ctxErr != nil {
+		return "", ctxErr
+	}
+
+	p.err = nil
+	result := p.forwardPhonemized(word)
+
+	if err == nil {
+		err = p.err
+	}
+	if err == nil {
+		err = p.checkHangul(original, result)
+	}
+
+	return result, err
+}
+
+// forwardPhonemized runs the rest of the pipeline after the phonemize step.
+func (p *pipeline) forwardPhonemized(word string) string {
 	word = p.normalize(word)
 
 	// transcribing phase
@@ -59,29 +121,57 @@ func (p *pipeline) input(word string) {
 // represent the exact pronunciation. But in some languages, such as American
 // English or Chinese, it's not true.
 //
-func (p *pipeline) phonemize(word string) (string, bool) {
-	id := p.h.spec.Lang.Phonemizer
-	if id == "" {
-		// The language doesn't require a phonemizer. It's okay.
-		return word, true
+// If the phonemizer implements ContextPhonemizer, the pipeline passes the
+// context and the phonemize options of the Hangulizer. When it fails or the
+// required phonemizer is not imported yet, the word is left unphonemized.
+//
+func (p *pipeline) phonemize(word string) (string, error) {
+	pron, ok := p.h.phonemizer()
+	if !ok {
+		if id := p.h.spec.Lang.Phonemizer; id != "" {
+			p.tr.TraceWarning("phonemize", fmt.Sprintf(`"%s" missing`, id), word)
+			return word, errors.Wrapf(ErrPhonemizerMissing, `"%s"`, id)
+		}
+
+		// The language doesn't require a phonemizer.
+		return word, nil
 	}
 
-	pron, ok := p.h.GetPhonemizer(id)
-	if ok {
-		goto PhonemizerFound
+	id := pron.ID()
+
+	phonemized, err := p.runPhonemizer(pron, word)
+	if err != nil {
+		return word, errors.Wrapf(err, `phonemizer "%s" failed`, id)
 	}
 
-	// Fallback by the global phonemizer registry.
-	pron, ok = GetPhonemizer(id)
-	if ok {
-		goto PhonemizerFound
+	p.tr.TraceWord("phonemize", id, phonemized)
+
+	return phonemized, nil
+}
+
+// runPhonemizer calls the most capable method of the phonemizer. If the
+// phonemizer supports segments, the word is always phonemized by the segments
+// so that HangulizeTrace never disagrees with Hangulize. Each segment is
+// traced.
+func (p *pipeline) runPhonemizer(pron Phonemizer, word string) (string, error) {
+	ctx := p.context()
+	opts := p.h.phonemizeOpts
+
+	if spron, ok := pron.(SegmentPhonemizer); ok {
+		segs, err := spron.PhonemizeSegments(ctx, word, opts)
+		if err != nil {
+			return word, err
+		}
+
+		p.tr.TraceSegments("phonemize", segs)
+		return joinReadings(segs), nil
 	}
 
-	// The language requires a phonemizer but not imported yet.
-	return word, false
+	if cpron, ok := pron.(ContextPhonemizer); ok {
+		return cpron.PhonemizeContext(ctx, word, opts)
+	}
 
-PhonemizerFound:
-	return pron.Phonemize(word), true
+	return pron.Phonemize(word), nil
 }
 
 // 2. Normalize (Word -> Word)
@@ -90,6 +180,9 @@ PhonemizerFound:
 //
 // For example, "Hello" in Latin script will be normalized to "hello".
 //
+// Some scripts normalize a whole word at first. For example, Pinyin splits
+// the syllables, such as "bei3jing1" to "bei jing".
+//
 func (p *pipeline) normalize(word string) string {
 	word = p.h.spec.normReplacer.Replace(word)
 
@@ -98,7 +191,12 @@ func (p *pipeline) normalize(word string) string {
 	script := p.h.spec.script
 	except := p.h.spec.normLetters
 
-	var buf bytes.Buffer
+	if wn, ok := script.(wordNormalizer); ok {
+		word = wn.NormalizeWord(word)
+	}
+
+	buf := &p.scratch().buf
+	buf.Reset()
 
 	for _, ch := range word {
 		if except.HasRune(ch) || !script.Is(ch) {
@@ -124,6 +222,9 @@ func (p *pipeline) normalize(word string) string {
 // For example, "hello, world!" will be grouped into
 // [{"hello",1}, {", ",0}, {"world",1}, {"!",0}].
 //
+// Hangul is always meaningless. So Hangul in the input, such as "서울" in
+// "서울 Milano", passes through the pipeline untouched.
+//
 func (p *pipeline) group(word string) []subword {
 	rep := newSubwordReplacer(word, 0, 1)
 
@@ -131,6 +232,9 @@ func (p *pipeline) group(word string) []subword {
 		let := string(ch)
 
 		switch {
+		case isHangul(ch):
+			// Hangul in the input is never transcribed.
+			continue
 		case p.h.spec.script.Is(ch):
 			fallthrough
 		case p.h.spec.puncts.HasRune(ch):
@@ -165,7 +269,12 @@ func (p *pipeline) rewrite(subwords []subword) []subword {
 		rep := newSubwordReplacer(word, level, 1)
 
 		for j, rule := range p.h.spec.Rewrite {
+			if !rule.enabled(p.h.features) {
+				continue
+			}
+
 			repls := rule.replacements(word)
+			repls = p.resolveOverlaps("rewrite", rule, word, "", repls)
 			rep.ReplaceBy(repls...)
 			word = rep.String()
 
@@ -214,7 +323,12 @@ func (p *pipeline) transcribe(subwords []subword) []subword {
 		dummy := newSubwordReplacer(word, 0, 0)
 
 		for j, rule := range p.h.spec.Transcribe {
+			if !rule.enabled(p.h.features) {
+				continue
+			}
+
 			repls := rule.replacements(word)
+			repls = p.resolveOverlaps("transcribe", rule, rep.word, word, repls)
 			rep.ReplaceBy(repls...)
 
 			for _, repl := range repls {
@@ -259,28 +373,51 @@ func (p *pipeline) transcribe(subwords []subword) []subword {
 //
 // For example, "ㅎㅔ-ㄹㄹㅗ" will be "헬로".
 //
+// If the spec has the orthography section, the composed syllables are checked
+// by the Korean loanword orthography. The violations are traced.
+//
 func (p *pipeline) compose(subwords []subword) string {
-	var buf bytes.Buffer
-	var jamoBuf bytes.Buffer
+	s := p.scratch()
+
+	buf := &s.buf
+	jamoBuf := &s.jamoBuf
+
+	buf.Reset()
+	jamoBuf.Reset()
+
+	var notes []string
+
+	// composeJamo composes the Jamo so far and checks the syllables by the
+	// orthography of the spec.
+	composeJamo := func() {
+		composed, violations := p.h.spec.Orthography.check(ComposeHangul(jamoBuf.String()))
+		jamoBuf.Reset()
+
+		buf.WriteString(composed)
+		notes = append(notes, violations...)
+	}
 
 	for _, sw := range subwords {
 		// Don't touch level=0 subwords. They just have passed through the
 		// pipeline, because they are meaningless.
 		if sw.level == 0 {
-			buf.WriteString(ComposeHangul(jamoBuf.String()))
-			jamoBuf.Reset()
+			composeJamo()
 
 			buf.WriteString(sw.word)
 			continue
 		}
 		jamoBuf.WriteString(sw.word)
 	}
-	buf.WriteString(ComposeHangul(jamoBuf.String()))
+	composeJamo()
 
 	word := buf.String()
 
 	p.tr.TraceWord("compose hangul", "", word)
 
+	for _, note := range notes {
+		p.tr.TraceWarning("orthography", note, word)
+	}
+
 	return word
 }
 
@@ -296,29 +433,40 @@ func (p *pipeline) compose(subwords []subword) string {
 //
 func (p *pipeline) transliterate(word string) string {
 	script := p.h.spec.script
+	s := p.scratch()
 
-	chars := []rune(word)
-	last := len(chars) - 1
+	s.chars = s.chars[:0]
+	for _, ch := range word {
+		s.chars = append(s.chars, ch)
+	}
+	last := len(s.chars) - 1
 
 	// Pre-evaluate punct or space classification.
-	isPunct := make(map[int]bool)
-	isSpace := make(map[int]bool)
-	for i, ch := range chars {
-		isPunct[i] = unicode.IsPunct(ch)
-		isSpace[i] = unicode.IsSpace(ch)
+	s.isPunct = s.isPunct[:0]
+	s.isSpace = s.isSpace[:0]
+	for _, ch := range s.chars {
+		s.isPunct = append(s.isPunct, unicode.IsPunct(ch))
+		s.isSpace = append(s.isSpace, unicode.IsSpace(ch))
+	}
+
+	// Out of the word is considered as a space.
+	isPunct := func(i int) bool {
+		return 0 <= i && i <= last && s.isPunct[i]
+	}
+	isSpace := func(i int) bool {
+		return i < 0 || last < i || s.isSpace[i]
 	}
-	isSpace[-1] = true
-	isSpace[last+1] = true
 
-	var buf bytes.Buffer
+	buf := &s.buf
+	buf.Reset()
 
-	for i, ch := range chars {
+	for i, ch := range s.chars {
 		// Skip ZWSP.
 		if ch == '\u200B' {
 			continue
 		}
 
-		if !isPunct[i] {
+		if !isPunct(i) {
 			buf.WriteRune(ch)
 			continue
 		}
@@ -327,13 +475,13 @@ func (p *pipeline) transliterate(word string) string {
 
 		// Trim left after punct or space.
 		l := i - 1
-		if isPunct[l] || isSpace[l] {
+		if isPunct(l) || isSpace(l) {
 			punct = strings.TrimLeftFunc(punct, unicode.IsSpace)
 		}
 
 		// Trim right before punct or space.
 		r := i + 1
-		if isPunct[r] || isSpace[r] {
+		if isPunct(r) || isSpace(r) {
 			punct = strings.TrimRightFunc(punct, unicode.IsSpace)
 		}
 
@@ -9,7 +9,7 @@ import (
 func TestTransliterate(t *testing.T) {
 	s := Spec{}
 	h := NewHangulizer(&s)
-	p := pipeline{h, nil}
+	p := pipeline{h: h}
 
 	s.script = _Kana{}
 
@@ -24,7 +24,7 @@ func TestTransliterate(t *testing.T) {
 func TestTransliterateZWSP(t *testing.T) {
 	s := Spec{}
 	h := NewHangulizer(&s)
-	p := pipeline{h, nil}
+	p := pipeline{h: h}
 
 	assert.Equal(t, "foo", p.transliterate("f\u200Bo\u200Bo"))
 }
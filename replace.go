@@ -47,7 +47,8 @@ func (r *subwordReplacer) Replace(start, stop int, word string) {
 	r.ReplaceBy(replacement{start, stop, word})
 }
 
-// ReplaceBy buffers multiple replacements.
+// ReplaceBy buffers multiple replacements. They must be sorted by the position
+// and must not overlap each other. resolveOverlaps guarantees it.
 func (r *subwordReplacer) ReplaceBy(repls ...replacement) {
 	r.repls = append(r.repls, repls...)
 }
@@ -0,0 +1,168 @@
+package hangulize
+
+import (
+	"bytes"
+	"context"
+	"html"
+	"strings"
+)
+
+// Ruby is a chunk of the original word annotated with the Hangul reading.
+type Ruby struct {
+	Base string // The original spelling, such as "新海".
+	Text string // The Hangul reading, such as "신카이". Empty if not annotated.
+}
+
+// HangulizeRuby transcribes a loanword into Hangul and annotates each chunk of
+// the original spelling with the reading. It is useful for a language which
+// requires a phonemizer, such as Japanese or Chinese:
+//
+//   新海誠 -> [新海](신카이) [誠](마코토)
+//
+// The chunks come from the segments of the phonemizer. Each segment is
+// transcribed alone to know how many syllables it takes. If the phonemizer
+// doesn't implement SegmentPhonemizer or the syllables can't be aligned, the
+// whole word is annotated.
+func (h *Hangulizer) HangulizeRuby(word string) []Ruby {
+	p := pipeline{h: h}
+
+	whole := func() []Ruby {
+		result, _ := p.forward(word)
+		return []Ruby{{word, result}}
+	}
+
+	pron, ok := h.phonemizer()
+	if !ok {
+		return whole()
+	}
+
+	spron, ok := pron.(SegmentPhonemizer)
+	if !ok {
+		return whole()
+	}
+
+	segs, err := spron.PhonemizeSegments(context.Background(), word, h.phonemizeOpts)
+	if err != nil {
+		return whole()
+	}
+
+	result := []rune(p.forwardPhonemized(joinReadings(segs)))
+	cur := 0
+
+	var rubies []Ruby
+
+	for _, seg := range segs {
+		// Separators are not in the original word. Keep them unannotated if
+		// they remain in the result, such as the space between a surname and
+		// a given name.
+		if seg.Surface == "" {
+			sep := []rune(seg.Reading)
+			if len(sep) != 0 && strings.HasPrefix(string(result[cur:]), seg.Reading) {
+				rubies = append(rubies, Ruby{Base: seg.Reading})
+				cur += len(sep)
+			}
+			continue
+		}
+
+		n := countSyllables(p.forwardPhonemized(seg.Reading))
+
+		// Skip the letters out of syllables, such as spaces. They belong to
+		// the previous segment.
+		if n != 0 {
+			for cur < len(result) && !isSyllable(result[cur]) {
+				cur++
+			}
+		}
+
+		start := cur
+		for n != 0 && cur < len(result) {
+			if isSyllable(result[cur]) {
+				n--
+			}
+			cur++
+		}
+
+		if n != 0 {
+			// The segment takes more syllables than the result has.
+			return whole()
+		}
+
+		text := string(result[start:cur])
+
+		if text == "" || text == seg.Surface {
+			// Nothing to annotate.
+			rubies = append(rubies, Ruby{Base: seg.Surface})
+		} else {
+			rubies = append(rubies, Ruby{seg.Surface, text})
+		}
+	}
+
+	// Every syllable should belong to a segment.
+	if countSyllables(string(result[cur:])) != 0 {
+		return whole()
+	}
+
+	return rubies
+}
+
+// RubyHTML renders rubies as HTML ruby markup:
+//
+//   <ruby>新海<rt>신카이</rt></ruby>
+//
+func RubyHTML(rubies []Ruby) string {
+	var buf bytes.Buffer
+
+	for _, r := range rubies {
+		if r.Text == "" {
+			buf.WriteString(html.EscapeString(r.Base))
+			continue
+		}
+
+		buf.WriteString("<ruby>")
+		buf.WriteString(html.EscapeString(r.Base))
+		buf.WriteString("<rt>")
+		buf.WriteString(html.EscapeString(r.Text))
+		buf.WriteString("</rt></ruby>")
+	}
+
+	return buf.String()
+}
+
+// RubyText renders rubies in a plain text format:
+//
+//   [新海](신카이)
+//
+func RubyText(rubies []Ruby) string {
+	var buf bytes.Buffer
+
+	for _, r := range rubies {
+		if r.Text == "" {
+			buf.WriteString(r.Base)
+			continue
+		}
+
+		buf.WriteString("[")
+		buf.WriteString(r.Base)
+		buf.WriteString("](")
+		buf.WriteString(r.Text)
+		buf.WriteString(")")
+	}
+
+	return buf.String()
+}
+
+// isSyllable checks whether the character is a composed Hangul syllable.
+func isSyllable(ch rune) bool {
+	return '가' <= ch && ch <= '힣'
+}
+
+// countSyllables counts composed Hangul syllables in a word.
+func countSyllables(word string) int {
+	n := 0
+	for _, ch := range word {
+		if isSyllable(ch) {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,81 @@
+package hangulize
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hangulize/hangulize/phonemize"
+)
+
+func TestHangulizeRuby(t *testing.T) {
+	h := NewHangulizer(ctxSpec)
+	h.UsePhonemizer(&segPhonemizer{})
+
+	rubies := h.HangulizeRuby("A?B")
+
+	assert.Equal(t, []Ruby{{"A", "아"}, {" ", ""}, {"?", ""}, {"B", "브"}}, rubies)
+	assert.Equal(t, "[A](아) ?[B](브)", RubyText(rubies))
+	assert.Equal(t,
+		"<ruby>A<rt>아</rt></ruby> ?<ruby>B<rt>브</rt></ruby>",
+		RubyHTML(rubies),
+	)
+}
+
+// namePhonemizer splits "AB" into a surname and a given name like furigana.
+type namePhonemizer struct {
+	ctxPhonemizer
+}
+
+func (namePhonemizer) PhonemizeSegments(
+	ctx context.Context,
+	word string,
+	opts map[string]interface{},
+) ([]phonemize.Segment, error) {
+	return []phonemize.Segment{
+		{Surface: "A", Reading: "a", Note: "personName"},
+		{Surface: "", Reading: " ", Note: "separator"},
+		{Surface: "B", Reading: "ab", Note: "personName"},
+	}, nil
+}
+
+func TestHangulizeRubySeparator(t *testing.T) {
+	h := NewHangulizer(ctxSpec)
+	h.UsePhonemizer(&namePhonemizer{})
+
+	rubies := h.HangulizeRuby("AB")
+
+	assert.Equal(t, []Ruby{{"A", "아"}, {" ", ""}, {"B", "아브"}}, rubies)
+	assert.Equal(t, "[A](아) [B](아브)", RubyText(rubies))
+}
+
+func TestHangulizeRubyWithoutSegments(t *testing.T) {
+	h := NewHangulizer(ctxSpec)
+	h.UsePhonemizer(&ctxPhonemizer{})
+
+	assert.Equal(t, []Ruby{{"ab", "아브"}}, h.HangulizeRuby("ab"))
+}
+
+func TestHangulizeRubyChi(t *testing.T) {
+	h := NewHangulizer(loadSpec("chi"))
+
+	rubies := h.HangulizeRuby("拼音")
+	assert.Equal(t, []Ruby{{"拼", "핀"}, {"音", "인"}}, rubies)
+}
+
+func TestRubyHTMLEscape(t *testing.T) {
+	assert.Equal(t,
+		"&lt;<ruby>A&amp;<rt>아</rt></ruby>",
+		RubyHTML([]Ruby{{"<", ""}, {"A&", "아"}}),
+	)
+}
+
+func ExampleHangulizer_HangulizeRuby() {
+	spec, _ := LoadSpec("jpn")
+	h := NewHangulizer(spec)
+
+	fmt.Println(RubyText(h.HangulizeRuby("新海誠")))
+	// Output: [新海](신카이) [誠](마코토)
+}
@@ -2,6 +2,10 @@ package hangulize
 
 import (
 	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/hangulize/hre"
 )
@@ -10,19 +14,144 @@ import (
 type Rule struct {
 	From *hre.Pattern
 	To   *hre.RPattern
+
+	// Optional flags annotated after the RPattern.
+	Scope RuleScope
+	Once  bool
+
+	// Features maps a feature to whether it must be enabled for the rule.
+	Features map[string]bool
+}
+
+// RuleScope restricts where a rule matches in a word. Like "^" and "$" in
+// HRE, a word is separated by any character which is not a letter, such as a
+// space or an apostrophe. So "acqua" in "l'acqua" is a word.
+type RuleScope int
+
+// Rule scopes.
+const (
+	ScopeAny     RuleScope = iota
+	ScopeInitial           // The match must start the word.
+	ScopeFinal             // The match must end the word.
+	ScopeWhole             // The match must be the whole word.
+)
+
+// Rule flags in HGL. They follow the RPattern:
+//
+//   rewrite:
+//       "ch"  -> "k", "initial", "once"
+//       "non" -> "넌", "whole"
+//
+// "initial", "final" and "whole" are the scopes. "once" replaces only the
+// first match in a word.
+//
+// There's no flag for the case. The rules always see the word in lower case
+// because the normalize step lowers the letters. An upper case letter in a
+// pattern is an internal marker introduced by the former rules.
+//
+// The rules see a subword at once, not the whole input. The letters which
+// the spec doesn't know, such as digits or Hangul, split the subwords. So a
+// "once" rule replaces the first match in each subword even if the subwords
+// are not separated by a space, like "a1a".
+//
+// A feature flag, such as "+archaic" or "-archaic", makes the rule work only
+// when the feature is enabled or disabled. See WithFeature.
+//
+// A variant spec removes an inherited rule by the "drop" flag. It never
+// reaches a Rule. See inheritRules.
+var ruleFlags = map[string]func(*Rule){
+	"initial": func(r *Rule) { r.Scope = ScopeInitial },
+	"final":   func(r *Rule) { r.Scope = ScopeFinal },
+	"whole":   func(r *Rule) { r.Scope = ScopeWhole },
+	"once":    func(r *Rule) { r.Once = true },
+}
+
+// flags returns the annotated flags in the HGL order.
+func (r *Rule) flags() []string {
+	var flags []string
+
+	switch r.Scope {
+	case ScopeInitial:
+		flags = append(flags, "initial")
+	case ScopeFinal:
+		flags = append(flags, "final")
+	case ScopeWhole:
+		flags = append(flags, "whole")
+	}
+
+	if r.Once {
+		flags = append(flags, "once")
+	}
+
+	var features []string
+	for feature, on := range r.Features {
+		if on {
+			features = append(features, "+"+feature)
+		} else {
+			features = append(features, "-"+feature)
+		}
+	}
+	sort.Slice(features, func(i, j int) bool {
+		return features[i][1:] < features[j][1:]
+	})
+
+	return append(flags, features...)
+}
+
+// setFeatureFlag sets a feature flag like "+archaic". It returns false if the
+// flag is not a feature flag.
+func (r *Rule) setFeatureFlag(flag string) bool {
+	if len(flag) < 2 || (flag[0] != '+' && flag[0] != '-') {
+		return false
+	}
+
+	if r.Features == nil {
+		r.Features = make(map[string]bool)
+	}
+	r.Features[flag[1:]] = flag[0] == '+'
+	return true
+}
+
+// enabled determines whether the rule works with the enabled features.
+func (r *Rule) enabled(features map[string]bool) bool {
+	for feature, on := range r.Features {
+		if features[feature] != on {
+			return false
+		}
+	}
+	return true
 }
 
 func (r *Rule) String() string {
-	return fmt.Sprintf("%s -> %s", r.From, r.To)
+	flags := r.flags()
+	if len(flags) == 0 {
+		return fmt.Sprintf("%s -> %s", r.From, r.To)
+	}
+	return fmt.Sprintf("%s -> %s (%s)", r.From, r.To, strings.Join(flags, ", "))
 }
 
 // replacements indicates which ranges should be replaced.
 func (r *Rule) replacements(word string) []replacement {
 	var repls []replacement
 
+	// The start of the last word replaced by a "once" rule.
+	replaced := -1
+
 	for _, m := range r.From.Find(word, -1) {
 		start, stop := m[0], m[1]
 
+		if !r.inScope(word, start, stop) {
+			continue
+		}
+
+		if r.Once {
+			ws := wordStart(word, start)
+			if ws == replaced {
+				continue
+			}
+			replaced = ws
+		}
+
 		repl, err := r.To.Interpolate(r.From, word, m)
 
 		if err != nil {
@@ -34,3 +163,44 @@ func (r *Rule) replacements(word string) []replacement {
 
 	return repls
 }
+
+// inScope determines whether a match is in the scope of the rule.
+func (r *Rule) inScope(word string, start, stop int) bool {
+	initial := start == 0
+	if !initial {
+		ch, _ := utf8.DecodeLastRuneInString(word[:start])
+		initial = isWordBoundary(ch)
+	}
+
+	final := stop == len(word)
+	if !final {
+		ch, _ := utf8.DecodeRuneInString(word[stop:])
+		final = isWordBoundary(ch)
+	}
+
+	switch r.Scope {
+	case ScopeInitial:
+		return initial
+	case ScopeFinal:
+		return final
+	case ScopeWhole:
+		return initial && final
+	}
+	return true
+}
+
+// isWordBoundary determines whether the character separates words. It agrees
+// with "^" and "$" in HRE.
+func isWordBoundary(ch rune) bool {
+	return !unicode.IsLetter(ch) && !unicode.IsMark(ch)
+}
+
+// wordStart finds the start of the word including the position.
+func wordStart(word string, pos int) int {
+	i := strings.LastIndexFunc(word[:pos], isWordBoundary)
+	if i == -1 {
+		return 0
+	}
+	_, size := utf8.DecodeRuneInString(word[i:])
+	return i + size
+}
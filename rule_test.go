@@ -0,0 +1,148 @@
+package hangulize
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleFlags(t *testing.T) {
+	spec := mustParseSpec(`
+	rewrite:
+		"a" -> "b", "initial", "once"
+		"c" -> "d"
+	`)
+	rule := spec.Rewrite[0]
+
+	assert.Equal(t, ScopeInitial, rule.Scope)
+	assert.True(t, rule.Once)
+	assert.True(t, strings.HasSuffix(rule.String(), " (initial, once)"))
+
+	assert.Equal(t, Rule{From: spec.Rewrite[1].From, To: spec.Rewrite[1].To}, *spec.Rewrite[1])
+}
+
+func TestRuleUnknownFlag(t *testing.T) {
+	_, err := ParseSpec(strings.NewReader(`
+	rewrite:
+		"a" -> "b", "twice"
+	`))
+	assert.Error(t, err)
+}
+
+func TestRuleScope(t *testing.T) {
+	spec := mustParseSpec(`
+	rewrite:
+		"a" -> "x", "initial"
+		"b" -> "y", "final"
+		"c" -> "z", "whole"
+
+	transcribe:
+		"x" -> "ㅅ"
+		"y" -> "ㅇ"
+		"z" -> "ㅈ"
+		"a" -> "ㅏ"
+		"b" -> "ㅂ"
+		"c" -> "ㅊ"
+	`)
+
+	h := NewHangulizer(spec)
+	assert.Equal(t, "사", h.Hangulize("aa"))
+	assert.Equal(t, "브으", h.Hangulize("bb"))
+	assert.Equal(t, "즈 츠츠", h.Hangulize("c cc"))
+}
+
+func TestRuleScopeBoundary(t *testing.T) {
+	rules := mustParseSpec(`
+	rewrite:
+		"a" -> "x", "initial"
+		"a" -> "y", "final"
+	`).Rewrite
+
+	// Not only a space but also an apostrophe separates words like "^".
+	repls := rules[0].replacements("l'acqua")
+	assert.Equal(t, []replacement{{2, 3, "x"}}, repls)
+
+	repls = rules[1].replacements("l'acqua-a")
+	assert.Equal(t, []replacement{{6, 7, "y"}, {8, 9, "y"}}, repls)
+}
+
+func TestRuleOnce(t *testing.T) {
+	spec := mustParseSpec(`
+	rewrite:
+		"a" -> "b", "once"
+
+	transcribe:
+		"a" -> "ㅏ"
+		"b" -> "ㅂ"
+	`)
+
+	h := NewHangulizer(spec)
+	assert.Equal(t, "바아", h.Hangulize("aaa"))
+	assert.Equal(t, "바 바", h.Hangulize("aa aa"))
+	assert.Equal(t, "바-바", h.Hangulize("aa-aa"))
+
+	repls := spec.Rewrite[0].replacements("aa'aa")
+	assert.Equal(t, []replacement{{0, 1, "b"}, {3, 4, "b"}}, repls)
+}
+
+func TestRuleNoIgnoreCase(t *testing.T) {
+	_, err := ParseSpec(strings.NewReader(`
+	rewrite:
+		"s" -> "x", "icase"
+	`))
+	assert.Error(t, err)
+
+	// The rules see the normalized word in lower case.
+	spec := mustParseSpec(`
+	rewrite:
+		"s" -> "S"
+
+	transcribe:
+		"S" -> "ㅅ"
+		"a" -> "ㅏ"
+	`)
+	assert.Equal(t, "사사", NewHangulizer(spec).Hangulize("SaSa"))
+}
+
+func TestRuleFeatures(t *testing.T) {
+	spec := mustParseSpec(`
+	features:
+		"archaic" = "The spelling before the 1996 reform"
+
+	rewrite:
+		"ß" -> "ss", "-archaic"
+		"ß" -> "s", "+archaic"
+
+	transcribe:
+		"a" -> "ㅏ"
+		"s" -> "ㅅ"
+	`)
+
+	assert.Equal(t, map[string]string{
+		"archaic": "The spelling before the 1996 reform",
+	}, spec.Features)
+	assert.Equal(t, map[string]bool{"archaic": false}, spec.Rewrite[0].Features)
+	assert.True(t, strings.HasSuffix(spec.Rewrite[1].String(), " (+archaic)"))
+
+	assert.Equal(t, "아스스", NewHangulizer(spec).Hangulize("aß"))
+	assert.Equal(t, "아스", NewHangulizer(spec, WithFeature("archaic")).Hangulize("aß"))
+}
+
+func TestWithUndeclaredFeature(t *testing.T) {
+	spec := mustParseSpec(`
+	features:
+		"archaic" = "The spelling before the 1996 reform"
+	`)
+
+	assert.Panics(t, func() { NewHangulizer(spec, WithFeature("archiac")) })
+	assert.NotPanics(t, func() { NewHangulizer(spec, WithFeature("archaic")) })
+}
+
+func TestRuleUndeclaredFeature(t *testing.T) {
+	_, err := ParseSpec(strings.NewReader(`
+	rewrite:
+		"ß" -> "s", "+archaic"
+	`))
+	assert.Error(t, err)
+}
@@ -1,6 +1,8 @@
 package hangulize
 
 import (
+	"bytes"
+	"strings"
 	"unicode"
 
 	"golang.org/x/text/unicode/norm"
@@ -13,6 +15,12 @@ type script interface {
 	TransliteratePunct(rune) string
 }
 
+// wordNormalizer is a script which normalizes a whole word before normalizing
+// each letter. It is for the script which needs the context of the letters.
+type wordNormalizer interface {
+	NormalizeWord(string) string
+}
+
 // scripts is the registry of Scripts by their name.
 var scripts = map[string]script{
 	// Latin is the default.
@@ -24,6 +32,8 @@ var scripts = map[string]script{
 	"kana":     &_Kana{},
 	"latin":    &_Latin{},
 	"pinyin":   &_Pinyin{},
+
+	"vietnamese": &_Vietnamese{},
 }
 
 // getScript chooses a script by the script name.
@@ -202,14 +212,102 @@ type _Pinyin struct {
 }
 
 // Normalize converts a Latin character for Pinyin into ISO basic Latin lower
-// alphabet [a-z]. Especially, it converts "ü" to "v":
+// alphabet [a-z]. Tone marks are removed. Especially, it converts "ü" to "v":
 //
 //   lüè -> lve
+//   lǜ  -> lv
 //
 func (s *_Pinyin) Normalize(ch rune) rune {
 	switch ch {
-	case 'ü', 'Ü':
+	case 'ü', 'Ü', 'ǖ', 'Ǖ', 'ǘ', 'Ǘ', 'ǚ', 'Ǚ', 'ǜ', 'Ǜ':
 		return 'v'
 	}
 	return s._Latin.Normalize(ch)
 }
+
+// NormalizeWord accepts both tone marks and tone numbers. It removes tone
+// numbers and splits the syllables with ZWSP:
+//
+//   Běijīng   -> Běi jīng
+//   bei3jing1 -> bei jing
+//   Xi'an     -> Xi an
+//
+func (s *_Pinyin) NormalizeWord(word string) string {
+	return normalizePinyinWord(s, word)
+}
+
+// -----------------------------------------------------------------------------
+
+// _Vietnamese represents the Latin script for Vietnamese. A Vietnamese vowel
+// may have a modifier and a tone mark together:
+//
+//   ệ = e + circumflex (modifier) + dot below (tone)
+//
+// Modifiers make different vowels but tones don't matter in Hangul.
+type _Vietnamese struct {
+	_Latin
+}
+
+// vietnameseLetters are the letters with modifiers in Vietnamese.
+const vietnameseLetters = "ăâêôơưđ"
+
+// Normalize converts a Latin character into lower case. It keeps the
+// modifiers of Vietnamese letters but removes the other diacritics:
+//
+//   Ơ -> ơ
+//   é -> e
+//
+func (s *_Vietnamese) Normalize(ch rune) rune {
+	ch = unicode.ToLower(ch)
+	if strings.ContainsRune(vietnameseLetters, ch) {
+		return ch
+	}
+	return s._Latin.Normalize(ch)
+}
+
+// isVietnameseTone checks whether the character is a combining tone mark:
+// grave (huyền), acute (sắc), hook above (hỏi), tilde (ngã) or dot below
+// (nặng).
+func isVietnameseTone(ch rune) bool {
+	switch ch {
+	case '\u0300', '\u0301', '\u0309', '\u0303', '\u0323':
+		return true
+	}
+	return false
+}
+
+// NormalizeWord removes the tone marks but keeps the modifiers. It accepts
+// both precomposed and combining sequences:
+//
+//   Nguyễn -> Nguyên
+//
+// Vietnamese is written syllable by syllable. It marks the boundaries of
+// run-together syllables with ZWSP. Camel case splits a word first. The rest
+// is segmented by the syllable structure:
+//
+//   ViệtNam   -> Viêt Nam
+//   nguyễnvăn -> nguyên văn
+//
+// See segmentVietnamese for the ambiguous syllables.
+//
+func (s *_Vietnamese) NormalizeWord(word string) string {
+	var buf bytes.Buffer
+	var prev rune
+
+	for _, ch := range norm.NFD.String(word) {
+		if isVietnameseTone(ch) {
+			continue
+		}
+
+		if unicode.IsUpper(ch) && unicode.IsLower(prev) {
+			buf.WriteRune('\u200B')
+		}
+		buf.WriteRune(ch)
+
+		if !unicode.Is(unicode.Mn, ch) {
+			prev = ch
+		}
+	}
+
+	return splitVietnameseSyllables(s, norm.NFC.String(buf.String()))
+}
@@ -17,3 +17,63 @@ func TestKanaNormalize(t *testing.T) {
 	assert.Equal(t, 'ア', kana.Normalize('あ'))
 	assert.Equal(t, 'ァ', kana.Normalize('ぁ'))
 }
+
+func TestPinyinNormalize(t *testing.T) {
+	pinyin := &_Pinyin{}
+	assert.Equal(t, 'v', pinyin.Normalize('ü'))
+	assert.Equal(t, 'v', pinyin.Normalize('ǚ'))
+	assert.Equal(t, 'e', pinyin.Normalize('Ě'))
+}
+
+func TestPinyinNormalizeWord(t *testing.T) {
+	pinyin := &_Pinyin{}
+	assert.Equal(t, "Běi\u200bjīng", pinyin.NormalizeWord("Běijīng"))
+	assert.Equal(t, "bei\u200bjing", pinyin.NormalizeWord("bei3jing1"))
+	assert.Equal(t, "Xi\u200ban", pinyin.NormalizeWord("Xi'an"))
+	assert.Equal(t, "xian", pinyin.NormalizeWord("xian"))
+	assert.Equal(t, "fan\u200bgan", pinyin.NormalizeWord("fangan"))
+	assert.Equal(t, "lü", pinyin.NormalizeWord("lu:4"))
+	assert.Equal(t, "Shang hai", pinyin.NormalizeWord("Shang4 hai3"))
+
+	// Not Pinyin
+	assert.Equal(t, "hello, world", pinyin.NormalizeWord("hello, world"))
+	assert.Equal(t, "R2D2", pinyin.NormalizeWord("R2D2"))
+}
+
+func TestSegmentPinyin(t *testing.T) {
+	assert.Equal(t, []int{5, 3}, segmentPinyin([]rune("zhongguo")))
+	assert.Equal(t, []int{4}, segmentPinyin([]rune("xian")))
+	assert.Nil(t, segmentPinyin([]rune("tiananmen")))
+	assert.Nil(t, segmentPinyin([]rune("hello")))
+}
+
+func TestVietnameseNormalize(t *testing.T) {
+	vie := &_Vietnamese{}
+	assert.Equal(t, 'ơ', vie.Normalize('Ơ'))
+	assert.Equal(t, 'đ', vie.Normalize('Đ'))
+	assert.Equal(t, 'e', vie.Normalize('é'))
+}
+
+func TestVietnameseNormalizeWord(t *testing.T) {
+	vie := &_Vietnamese{}
+	assert.Equal(t, "Nguyên", vie.NormalizeWord("Nguyễn"))
+	assert.Equal(t, "Nguyên", vie.NormalizeWord("Nguye\u0302\u0303n"))
+	assert.Equal(t, "Hô Chi Minh", vie.NormalizeWord("Hồ Chí Minh"))
+	assert.Equal(t, "ươ", vie.NormalizeWord("ườ"))
+	assert.Equal(t, "Viêt\u200bNam", vie.NormalizeWord("ViệtNam"))
+	assert.Equal(t, "nguyên\u200bvan", vie.NormalizeWord("nguyễnvan"))
+	assert.Equal(t, "Hô\u200bchi\u200bminh", vie.NormalizeWord("Hồchíminh"))
+
+	// Not Vietnamese
+	assert.Equal(t, "Pleiku", vie.NormalizeWord("Pleiku"))
+}
+
+func TestSegmentVietnamese(t *testing.T) {
+	assert.Equal(t, []int{6, 3}, segmentVietnamese([]rune("nguyenvan")))
+	assert.Equal(t, []int{2, 3}, segmentVietnamese([]rune("hanoi")))
+	assert.Equal(t, []int{2, 3, 4}, segmentVietnamese([]rune("hochiminh")))
+	assert.Equal(t, []int{4, 3}, segmentVietnamese([]rune("vietnam")))
+	assert.Equal(t, []int{7}, segmentVietnamese([]rune("nghieng")))
+	assert.Equal(t, []int{4}, segmentVietnamese([]rune("quoc")))
+	assert.Nil(t, segmentVietnamese([]rune("hello")))
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 	"unicode"
 	"unicode/utf8"
 
@@ -26,16 +27,26 @@ type Spec struct {
 	Vars      map[string][]string
 	Normalize map[string][]string
 
+	// Named features which toggle rules, and their descriptions
+	Features map[string]string
+
 	// Rewrite/Transcribe
 	Rewrite    []*Rule
 	Transcribe []*Rule
 
+	// Constraints on the composed syllables
+	Orthography Orthography
+
 	// Test examples
 	Test [][2]string
 
 	// Source code
 	Source string
 
+	// The rule pairs before compiled. A variant spec compiles them again.
+	rewritePairs    []hgl.Pair
+	transcribePairs []hgl.Pair
+
 	// Prepared stuffs
 	script script
 	puncts stringset.StringSet
@@ -49,8 +60,27 @@ func (s *Spec) String() string {
 	return fmt.Sprintf("<Spec lang=%s>", s.Lang.ID)
 }
 
-// ParseSpec parses a Spec from an HGL source.
+// ParseSpec parses a Spec from an HGL source. The base spec of a variant spec
+// is loaded by LoadSpec.
 func ParseSpec(r io.Reader) (*Spec, error) {
+	return ParseSpecWithLoader(r, LoadSpec)
+}
+
+// ParseSpecWithLoader parses a Spec from an HGL source like ParseSpec. But the
+// base spec of a variant spec is loaded by the given loader.
+//
+// A variant spec extends a base spec by "extends" in the "lang" section:
+//
+//   lang:
+//       id      = "kat-2"
+//       extends = "kat-1"
+//
+// Then it inherits "vars", "macros", "normalize", "features", "orthography"
+// and the rules from the base spec. The inherited rules are compiled again
+// with the merged vars and macros. See inheritRules for how the rules are
+// merged.
+//
+func ParseSpecWithLoader(r io.Reader, load SpecLoader) (*Spec, error) {
 	var err error
 	var sourceBuf bytes.Buffer
 
@@ -65,6 +95,10 @@ func ParseSpec(r io.Reader) (*Spec, error) {
 
 	source := sourceBuf.String()
 
+	if err := checkSections(h); err != nil {
+		return nil, err
+	}
+
 	// -------------------------------------------------------------------------
 	// Every sections are optional. An empty HGL source is also valid spec.
 
@@ -81,6 +115,26 @@ func ParseSpec(r io.Reader) (*Spec, error) {
 		lang = *_lang
 	}
 
+	// extends
+	var base Spec
+
+	if lang.Extends != "" {
+		_base, ok := load(lang.Extends)
+
+		if !ok {
+			return nil, errors.Errorf("base spec not found: %#v", lang.Extends)
+		}
+
+		base = *_base
+
+		if lang.Script == "" {
+			lang.Script = base.Lang.Script
+		}
+		if lang.Phonemizer == "" {
+			lang.Phonemizer = base.Lang.Phonemizer
+		}
+	}
+
 	// config
 	var config Config
 
@@ -104,26 +158,45 @@ func ParseSpec(r io.Reader) (*Spec, error) {
 			return nil, err
 		}
 	}
+	macros = inheritMacros(base.Macros, macros)
 
 	// vars
 	var vars map[string][]string
 	if sec, ok := h["vars"]; ok {
 		vars = sec.(*hgl.DictSection).Map()
 	}
+	vars = inheritDict(base.Vars, vars)
 
 	// normalize
 	var normalize map[string][]string
 	if sec, ok := h["normalize"]; ok {
 		normalize = sec.(*hgl.DictSection).Map()
 	}
+	normalize = inheritDict(base.Normalize, normalize)
+
+	// features
+	var features map[string]string
+
+	if sec, ok := h["features"]; ok {
+		features, err = sec.(*hgl.DictSection).Injective()
+
+		if err != nil {
+			return nil, err
+		}
+	}
+	features = inheritMacros(base.Features, features)
 
 	// rewrite
 	var rewritePairs []hgl.Pair
 	if sec, ok := h["rewrite"]; ok {
 		rewritePairs = sec.(*hgl.ListSection).Array()
 	}
+	rewritePairs, err = inheritRules(base.rewritePairs, rewritePairs)
+	if err != nil {
+		return nil, err
+	}
 
-	rewrite, err := newRules(rewritePairs, macros, vars)
+	rewrite, err := newRules(rewritePairs, macros, vars, features)
 	if err != nil {
 		return nil, err
 	}
@@ -133,16 +206,37 @@ func ParseSpec(r io.Reader) (*Spec, error) {
 	if sec, ok := h["transcribe"]; ok {
 		transcribePairs = sec.(*hgl.ListSection).Array()
 	}
+	transcribePairs, err = inheritRules(base.transcribePairs, transcribePairs)
+	if err != nil {
+		return nil, err
+	}
 
-	transcribe, err := newRules(transcribePairs, macros, vars)
+	transcribe, err := newRules(transcribePairs, macros, vars, features)
 	if err != nil {
 		return nil, err
 	}
 
+	// orthography
+	orthography := base.Orthography
+
+	if sec, ok := h["orthography"]; ok {
+		_orthography, err := newOrthography(sec.(*hgl.DictSection))
+
+		if err != nil {
+			return nil, err
+		}
+
+		orthography = *_orthography
+	}
+
 	// test
 	var test [][2]string
 	if sec, ok := h["test"]; ok {
 		for _, pair := range sec.(*hgl.ListSection).Array() {
+			if len(pair.Right()) == 0 {
+				return nil, errors.Errorf("no transcription of %#v", pair.Left())
+			}
+
 			word := pair.Left()
 			transcribed := pair.Right()[0]
 
@@ -182,13 +276,20 @@ func ParseSpec(r io.Reader) (*Spec, error) {
 		vars,
 		normalize,
 
+		features,
+
 		rewrite,
 		transcribe,
 
+		orthography,
+
 		test,
 
 		source,
 
+		rewritePairs,
+		transcribePairs,
+
 		script,
 		puncts,
 
@@ -198,6 +299,34 @@ func ParseSpec(r io.Reader) (*Spec, error) {
 	return &spec, nil
 }
 
+// Sections by the kind.
+var (
+	dictSections = []string{"lang", "config", "macros", "vars", "normalize", "features", "orthography"}
+	listSections = []string{"rewrite", "transcribe", "test"}
+)
+
+// checkSections checks whether each section is the expected kind. A dict
+// section has "=" pairs and a list section has "->" pairs.
+func checkSections(h hgl.HGL) error {
+	for _, name := range dictSections {
+		if sec, ok := h[name]; ok {
+			if _, ok := sec.(*hgl.DictSection); !ok {
+				return errors.Errorf(`"%s" section must have "=" pairs`, name)
+			}
+		}
+	}
+
+	for _, name := range listSections {
+		if sec, ok := h[name]; ok {
+			if _, ok := sec.(*hgl.ListSection); !ok {
+				return errors.Errorf(`"%s" section must have "->" pairs`, name)
+			}
+		}
+	}
+
+	return nil
+}
+
 // -----------------------------------------------------------------------------
 // "lang" section
 
@@ -209,6 +338,7 @@ type Language struct {
 	Korean     string    // The language name in Korean.
 	Script     string
 	Phonemizer string
+	Extends    string // The ID of the base spec.
 }
 
 func (l *Language) String() string {
@@ -233,6 +363,7 @@ func newLanguage(dict *hgl.DictSection) (*Language, error) {
 		Korean:     dict.One("korean"),
 		Script:     dict.One("script"),
 		Phonemizer: dict.One("phonemizer"),
+		Extends:    dict.One("extends"),
 	}
 	return &lang, nil
 }
@@ -242,14 +373,59 @@ func newLanguage(dict *hgl.DictSection) (*Language, error) {
 
 // Config keeps some configurations for a transactiption specification.
 type Config struct {
-	Authors []string
-	Stage   string
+	Authors    []string
+	Stage      Stage
+	Version    string
+	License    string
+	References []string  // Official rules, such as the 국립국어원 ones.
+	Reviewed   time.Time // The date when the spec has been reviewed last.
 }
 
+// Stage is the maturity of a spec.
+type Stage string
+
+// Stages of a spec.
+const (
+	StageDraft  Stage = "draft"
+	StageBeta   Stage = "beta"
+	StageStable Stage = "stable"
+)
+
 func newConfig(dict *hgl.DictSection) (*Config, error) {
+	// Both "author" and "authors" are accepted.
+	var authors []string
+	authors = append(authors, dict.All("author")...)
+	authors = append(authors, dict.All("authors")...)
+
+	stage := Stage(dict.One("stage"))
+
+	switch stage {
+	case "":
+		stage = StageDraft
+	case StageDraft, StageBeta, StageStable:
+	default:
+		return nil, errors.Errorf(`stage must be "%s", "%s" or "%s": %#v`,
+			StageDraft, StageBeta, StageStable, stage)
+	}
+
+	var reviewed time.Time
+
+	if _reviewed := dict.One("reviewed"); _reviewed != "" {
+		var err error
+		reviewed, err = time.Parse("2006-01-02", _reviewed)
+
+		if err != nil {
+			return nil, errors.Wrap(err, "reviewed must be a date like 2006-01-02")
+		}
+	}
+
 	config := Config{
-		Authors: dict.All("authors"),
-		Stage:   dict.One("stage"),
+		Authors:    authors,
+		Stage:      stage,
+		Version:    dict.One("version"),
+		License:    dict.One("license"),
+		References: dict.All("references"),
+		Reviewed:   reviewed,
 	}
 	return &config, nil
 }
@@ -262,6 +438,7 @@ func newRules(
 
 	macros map[string]string,
 	vars map[string][]string,
+	features map[string]string,
 
 ) ([]*Rule, error) {
 
@@ -274,9 +451,33 @@ func newRules(
 		}
 
 		right := pair.Right()
+		if len(right) == 0 {
+			return nil, errors.Errorf("no replacement of %#v", pair.Left())
+		}
 		to := hre.NewRPattern(right[0], macros, vars)
 
-		rules[i] = &Rule{from, to}
+		rule := Rule{From: from, To: to}
+
+		// The rest are flags.
+		for _, flag := range right[1:] {
+			if rule.setFeatureFlag(flag) {
+				continue
+			}
+
+			setFlag, ok := ruleFlags[flag]
+			if !ok {
+				return nil, errors.Errorf("unknown rule flag of %#v: %#v", pair.Left(), flag)
+			}
+			setFlag(&rule)
+		}
+
+		for feature := range rule.Features {
+			if _, ok := features[feature]; !ok {
+				return nil, errors.Errorf("undeclared feature of %#v: %#v", pair.Left(), feature)
+			}
+		}
+
+		rules[i] = &rule
 	}
 
 	return rules, nil
@@ -1,7 +1,9 @@
 package hangulize
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -21,3 +23,59 @@ func TestSpecSource(t *testing.T) {
 		"hello" -> "world"
 	`, spec.Source)
 }
+
+func TestConfig(t *testing.T) {
+	spec := mustParseSpec(`
+	config:
+		authors    = "John Doe", "Jane Doe"
+		stage      = "beta"
+		version    = "1.2"
+		license    = "MIT"
+		references = "https://kornorms.korean.go.kr/"
+		reviewed   = "2018-05-01"
+	`)
+	assert.Equal(t, []string{"John Doe", "Jane Doe"}, spec.Config.Authors)
+	assert.Equal(t, StageBeta, spec.Config.Stage)
+	assert.Equal(t, "1.2", spec.Config.Version)
+	assert.Equal(t, "MIT", spec.Config.License)
+	assert.Equal(t, []string{"https://kornorms.korean.go.kr/"}, spec.Config.References)
+	assert.Equal(t, time.Date(2018, 5, 1, 0, 0, 0, 0, time.UTC), spec.Config.Reviewed)
+}
+
+func TestConfigAuthor(t *testing.T) {
+	spec := mustParseSpec(`
+	config:
+		author = "John Doe"
+	`)
+	assert.Equal(t, []string{"John Doe"}, spec.Config.Authors)
+	assert.Equal(t, StageDraft, spec.Config.Stage)
+	assert.True(t, spec.Config.Reviewed.IsZero())
+}
+
+func TestConfigInvalid(t *testing.T) {
+	_, err := ParseSpec(strings.NewReader(`
+	config:
+		stage = "alpha"
+	`))
+	assert.Error(t, err)
+
+	_, err = ParseSpec(strings.NewReader(`
+	config:
+		reviewed = "May 1, 2018"
+	`))
+	assert.Error(t, err)
+}
+
+func TestSectionKind(t *testing.T) {
+	_, err := ParseSpec(strings.NewReader(`
+	lang:
+		"id" -> "ita"
+	`))
+	assert.Error(t, err)
+
+	_, err = ParseSpec(strings.NewReader(`
+	rewrite:
+		"a" = "b"
+	`))
+	assert.Error(t, err)
+}
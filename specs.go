@@ -31,9 +31,30 @@ func ListLangs() []string {
 	return langs
 }
 
+// ListSpecs returns the bundled specs sorted by the language ID. It is useful
+// to build a language picker with the metadata of each spec:
+//
+//   for _, spec := range ListSpecs() {
+//     fmt.Println(spec.Lang.Korean, spec.Config.Stage)
+//   }
+//
+func ListSpecs() []*Spec {
+	var specs []*Spec
+
+	for _, lang := range ListLangs() {
+		spec, _ := LoadSpec(lang)
+		specs = append(specs, spec)
+	}
+
+	return specs
+}
+
 // Cached specs.
 var specs = make(map[string]*Spec)
 
+// The specs being loaded. It prevents a cycle of base specs.
+var loading = make(map[string]bool)
+
 // LoadSpec finds a bundled spec by the given language name.
 // Once it loads a spec, it will cache the spec.
 func LoadSpec(lang string) (*Spec, bool) {
@@ -47,11 +68,14 @@ func LoadSpec(lang string) (*Spec, bool) {
 
 	filename := lang + ext
 
-	if !hgls.Has(filename) {
+	if !hgls.Has(filename) || loading[lang] {
 		// not found
 		return nil, false
 	}
 
+	loading[lang] = true
+	defer delete(loading, lang)
+
 	hgl := hgls.String(filename)
 	spec, err := ParseSpec(strings.NewReader(hgl))
 
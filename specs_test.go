@@ -3,6 +3,8 @@ package hangulize
 import (
 	"fmt"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 // Here're all supported languages.
@@ -51,6 +53,46 @@ func ExampleListLangs() {
 	// ukr
 	// vie
 	// wlm
+	// yue
+}
+
+func TestListSpecs(t *testing.T) {
+	specs := ListSpecs()
+	assert.Len(t, specs, len(ListLangs()))
+
+	for _, spec := range specs {
+		assert.NotEmpty(t, spec.Config.Authors, spec.Lang.ID)
+		assert.NotEmpty(t, spec.Config.Stage, spec.Lang.ID)
+	}
+}
+
+// knownOrthographyIssues are the test words of the specs in the "flag"
+// orthography mode whose results violate the orthography. They should be
+// fixed in the specs someday.
+var knownOrthographyIssues = map[string][]string{}
+
+func TestOrthographyFlagged(t *testing.T) {
+	for _, spec := range ListSpecs() {
+		if spec.Orthography.Mode != OrthographyFlag {
+			continue
+		}
+
+		h := NewHangulizer(spec)
+
+		var issues []string
+		for _, test := range spec.Test {
+			_, tr := h.HangulizeTrace(test[0])
+
+			for _, step := range tr {
+				if step.Step == "orthography" {
+					issues = append(issues, test[0])
+					break
+				}
+			}
+		}
+
+		assert.Equal(t, knownOrthographyIssues[spec.Lang.ID], issues, spec.Lang.ID)
+	}
 }
 
 // -----------------------------------------------------------------------------
@@ -138,3 +180,51 @@ func TestChiV(t *testing.T) {
 	assertHangulize(t, chi, "뤼", "Lv")
 	assertHangulize(t, chi, "뤼", "Lü")
 }
+
+func TestChiToneMarks(t *testing.T) {
+	chi := loadSpec("chi")
+
+	assertHangulize(t, chi, "베이징", "Běijīng")
+	assertHangulize(t, chi, "뤼", "Lǚ")
+	assertHangulize(t, chi, "상하이", "Shànghǎi")
+}
+
+func TestChiToneNumbers(t *testing.T) {
+	chi := loadSpec("chi")
+
+	assertHangulize(t, chi, "베이징", "bei3jing1")
+	assertHangulize(t, chi, "뤼", "lu:3")
+	assertHangulize(t, chi, "상 하이", "Shang4 hai3")
+}
+
+func TestChiSyllables(t *testing.T) {
+	chi := loadSpec("chi")
+
+	assertHangulize(t, chi, "셴", "xian")
+	assertHangulize(t, chi, "시안", "Xi'an")
+	assertHangulize(t, chi, "시안", "xi1an1")
+	assertHangulize(t, chi, "판간", "fangan")
+	assertHangulize(t, chi, "팡안", "fang'an")
+}
+
+// -----------------------------------------------------------------------------
+// Dutch
+
+func TestNldArchaic(t *testing.T) {
+	// The old spelling writes y for ij.
+	h := NewHangulizer(loadSpec("nld"), WithFeature("archaic"))
+
+	assert.Equal(t, "네이메헌", h.Hangulize("Nymegen"))
+	assert.Equal(t, "베베르베이크", h.Hangulize("Beverwyk"))
+
+	// The same with the modern spelling.
+	assert.Equal(t, h.Hangulize("Nijmegen"), h.Hangulize("Nymegen"))
+	assert.Equal(t, Hangulize("nld", "Beverwijk"), h.Hangulize("Beverwyk"))
+
+	// After a vowel, y is read as usual.
+	assert.Equal(t, "하위헌스", h.Hangulize("Huygens"))
+	assert.Equal(t, "페르베이", h.Hangulize("Verwey"))
+
+	// Without the feature, y is i.
+	assert.Equal(t, "니메헌", Hangulize("nld", "Nymegen"))
+}
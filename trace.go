@@ -1,8 +1,11 @@
 package hangulize
 
 import (
+	"bytes"
 	"fmt"
 	"strings"
+
+	"github.com/hangulize/hangulize/phonemize"
 )
 
 // Trace is emitted when a replacement occurs. It is used for tracing of
@@ -51,6 +54,50 @@ func (tr *tracer) TraceSubwords(step, why string, subwords []subword) {
 	tr.trace(step, why, word)
 }
 
+// TraceWarning traces a warning about the word. It traces even if the word
+// has not been changed.
+func (tr *tracer) TraceWarning(step, why, word string) {
+	if tr == nil {
+		return
+	}
+	tr.traces = append(tr.traces, Trace{step, why, word})
+	tr.lastWord = word
+}
+
+// TraceSegments traces phonemized segments one by one. Each trace shows the
+// word where the segments so far have been replaced with their readings.
+//
+// Unlike the other traces, it traces even if a segment doesn't change the
+// word. Because it is worth to know an unknown segment left as it is.
+func (tr *tracer) TraceSegments(step string, segs []phonemize.Segment) {
+	if tr == nil {
+		return
+	}
+
+	var buf bytes.Buffer
+
+	for i, seg := range segs {
+		if seg.Surface == "" {
+			// Just a separator.
+			continue
+		}
+
+		buf.Reset()
+		for _, done := range segs[:i+1] {
+			buf.WriteString(done.Reading)
+		}
+		for _, rest := range segs[i+1:] {
+			buf.WriteString(rest.Surface)
+		}
+
+		why := fmt.Sprintf("%#v -> %#v (%s)", seg.Surface, seg.Reading, seg.Note)
+		word := buf.String()
+
+		tr.traces = append(tr.traces, Trace{step, why, word})
+		tr.lastWord = word
+	}
+}
+
 type ruleTracer struct {
 	tr           *tracer
 	subwords     []subword
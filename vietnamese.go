@@ -0,0 +1,153 @@
+package hangulize
+
+import (
+	"bytes"
+	"unicode"
+)
+
+// vietnameseOnsets is the set of the initial consonants in Vietnamese. "đ" is
+// written as "d" and "qu" is an onset.
+var vietnameseOnsets = makeSyllables(`
+	b c ch d g gh gi h k kh l m n ng ngh nh p ph qu r s t th tr v x
+`)
+
+// vietnameseRhymes is the set of the rhymes in Vietnamese. A rhyme is the
+// rest of a syllable after the onset. The modifiers are removed:
+//
+//   ươ -> uo
+//   ê  -> e
+//
+var vietnameseRhymes = makeSyllables(`
+	a ac ach ai am an ang anh ao ap at au ay
+	e ec ech em en eng enh eo ep et eu
+	i ia ich im in inh ip it iu
+	iec iem ien ieng iep iet ieu
+	o oc oi om on ong ooc oong op ot
+	oa oac oach oai oam oan oang oanh oao oap oat oay
+	oe oen oeo oet
+	u ua uc ui um un ung up ut uu uy
+	uan uang uat uay ue uech uenh uo
+	uoc uoi uom uon uong uop uot uou
+	uya uych uyen uyet uyn uynh uyp uyt uyu
+	y yen yet yeu
+`)
+
+// maxVietnameseSyllable is the length of the longest Vietnamese syllable,
+// such as "nghiêng".
+const maxVietnameseSyllable = 7
+
+// baseVietnamese removes the modifier of a Vietnamese letter in lower case:
+//
+//   Ơ -> o
+//   đ -> d
+//
+func baseVietnamese(ch rune) rune {
+	ch = unicode.ToLower(ch)
+	switch ch {
+	case 'ă', 'â':
+		return 'a'
+	case 'ê':
+		return 'e'
+	case 'ô', 'ơ':
+		return 'o'
+	case 'ư':
+		return 'u'
+	case 'đ':
+		return 'd'
+	}
+	return ch
+}
+
+// isVietnameseSyllable checks whether the letters make a syllable, an onset
+// followed by a rhyme. The letters should be the base letters by
+// baseVietnamese.
+func isVietnameseSyllable(letters []rune) bool {
+	for i := range letters {
+		onset, rhyme := string(letters[:i]), string(letters[i:])
+		if i != 0 && !vietnameseOnsets[onset] {
+			continue
+		}
+		if vietnameseRhymes[rhyme] {
+			return true
+		}
+	}
+	return false
+}
+
+// segmentVietnamese splits run-together Vietnamese into syllables. The
+// letters should be the base letters by baseVietnamese. It returns the length
+// of each syllable. If the letters are not Vietnamese, it returns nil.
+//
+// It prefers the fewest syllables. Among them, a consonant between vowels
+// begins the next syllable:
+//
+//   nguyenvan -> nguyen van
+//   hanoi     -> ha noi
+//   hochiminh -> ho chi minh
+//
+// So it can't tell "Thanh Hóa" from "tha nhoa". Write the spaces if the
+// syllables are ambiguous.
+func segmentVietnamese(letters []rune) []int {
+	// best[i] is the segmentation of letters[i:]. nil if it can't be
+	// segmented.
+	best := make([][]int, len(letters)+1)
+	best[len(letters)] = []int{}
+
+	for i := len(letters) - 1; i >= 0; i-- {
+		n := maxVietnameseSyllable
+		if n > len(letters)-i {
+			n = len(letters) - i
+		}
+
+		// Prefer the shorter syllable for the same number of syllables.
+		for j := 1; j <= n; j++ {
+			rest := best[i+j]
+			if rest == nil || !isVietnameseSyllable(letters[i:i+j]) {
+				continue
+			}
+			if best[i] == nil || len(rest)+1 < len(best[i]) {
+				best[i] = append([]int{j}, rest...)
+			}
+		}
+	}
+
+	return best[0]
+}
+
+// splitVietnameseSyllables marks the syllable boundaries in the runs of
+// letters with ZWSP. A run which is not Vietnamese is kept as it is.
+func splitVietnameseSyllables(s script, word string) string {
+	var buf bytes.Buffer
+	var run []rune
+
+	flush := func() {
+		letters := make([]rune, len(run))
+		for i, ch := range run {
+			letters[i] = baseVietnamese(ch)
+		}
+
+		i := 0
+		for j, n := range segmentVietnamese(letters) {
+			if j != 0 {
+				buf.WriteRune('\u200B')
+			}
+			buf.WriteString(string(run[i : i+n]))
+			i += n
+		}
+		buf.WriteString(string(run[i:]))
+
+		run = run[:0]
+	}
+
+	for _, ch := range word {
+		if s.Is(ch) {
+			run = append(run, ch)
+			continue
+		}
+		flush()
+		buf.WriteRune(ch)
+	}
+	flush()
+
+	return buf.String()
+}
@@ -0,0 +1,41 @@
+package hangulize
+
+import "github.com/hangulize/hangulize/phonemize"
+
+// WordClass is a hint about what a word is. Transcription conventions differ
+// between person names and place names.
+type WordClass string
+
+// Word classes.
+const (
+	CommonNoun WordClass = phonemize.CommonNoun
+	PersonName WordClass = phonemize.PersonName // A full name.
+	GivenName  WordClass = phonemize.GivenName  // Only a given name.
+	Surname    WordClass = phonemize.Surname    // Only a surname.
+	PlaceName  WordClass = phonemize.PlaceName
+)
+
+// WithWordClass gives a Hangulizer a hint about what the words are. It is
+// useful when the caller knows the data, such as a people database.
+//
+// The hint is passed to the phonemizer as the phonemize.WordClassOption
+// option. It also enables the feature named after the class if the spec
+// declares it. So the spec can flag the rules for the class:
+//
+//   features:
+//       "person" = "Person names"
+//
+//   rewrite:
+//       "^mc" -> "mak", "+person"
+//
+// GivenName and Surname enable the "person" feature too.
+func WithWordClass(class WordClass) Option {
+	return func(h *Hangulizer) {
+		WithPhonemizeOption(phonemize.WordClassOption, string(class))(h)
+		h.enableFeature(string(class))
+
+		if class == GivenName || class == Surname {
+			h.enableFeature(string(PersonName))
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package hangulize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hangulize/hangulize/phonemize"
+)
+
+func TestWithWordClass(t *testing.T) {
+	spec := mustParseSpec(`
+	features:
+		"person"  = "Person names"
+		"surname" = "Surnames"
+		"place"   = "Place names"
+	`)
+
+	h := NewHangulizer(spec, WithWordClass(Surname))
+
+	assert.Equal(t, "surname", h.phonemizeOpts[phonemize.WordClassOption])
+	assert.Equal(t, map[string]bool{"surname": true, "person": true}, h.features)
+
+	h = NewHangulizer(spec, WithWordClass(PlaceName))
+	assert.Equal(t, map[string]bool{"place": true}, h.features)
+
+	// Only the declared features are enabled.
+	h = NewHangulizer(mustParseSpec(``), WithWordClass(GivenName))
+	assert.Equal(t, "given-name", h.phonemizeOpts[phonemize.WordClassOption])
+	assert.Empty(t, h.features)
+}
+
+func TestWordClassFeature(t *testing.T) {
+	spec := mustParseSpec(`
+	features:
+		"person" = "Person names"
+
+	rewrite:
+		"^mc" -> "mag", "+person"
+
+	transcribe:
+		"m" -> "ㅁ"
+		"a" -> "ㅏ"
+		"g" -> "-ㄱ"
+		"c" -> "ㅋ"
+	`)
+
+	assert.Equal(t, "므크", NewHangulizer(spec).Hangulize("mc"))
+	assert.Equal(t, "막", NewHangulizer(spec, WithWordClass(PersonName)).Hangulize("mc"))
+	assert.Equal(t, "막", NewHangulizer(spec, WithWordClass(Surname)).Hangulize("mc"))
+	assert.Equal(t, "므크", NewHangulizer(spec, WithWordClass(PlaceName)).Hangulize("mc"))
+}